@@ -0,0 +1,30 @@
+// Command oasgen generates a Go HTTP client from an OpenAPI 3 document.
+//
+// Usage:
+//
+//	oasgen -spec openapi.yaml [-out client_gen.go] [-package client] [-client-name Client]
+//	oasgen watch [flags]
+//
+// Settings may also be read from a config file (-config, or oasgen.yaml,
+// oasgen.yml or oasgen.json in the working directory). Flags given on the
+// command line take precedence over the config file.
+//
+// With -dry-run nothing is written and the files that would change are
+// listed. With -diff a unified diff against the files on disk is printed
+// instead, and oasgen exits with status 3 if the generated code is stale,
+// which lets CI check that checked-in code is up to date.
+//
+// The watch subcommand takes the same flags, regenerates once, and then
+// regenerates whenever the spec, a file it references, the config file or a
+// template changes, until interrupted.
+package main
+
+import (
+	"os"
+
+	"github.com/bgw7/codegen-oas_http/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,53 @@
+package apiClient
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// additionalField is the name of the struct field holding the additional
+// properties of an object.
+const additionalField = "AdditionalProperties"
+
+// additionalType returns the Go type of the additional properties of an
+// object schema, when they are allowed explicitly: interface{} for
+// "additionalProperties: true", or the type of the schema given. Values of
+// inline enums and the like are named after g.typeAt with a Value suffix.
+func (g *generator) additionalType(schema *base.Schema) (string, bool) {
+	ap := schema.AdditionalProperties
+	switch {
+	case ap == nil:
+		return "", false
+	case ap.IsA():
+		typeAt := g.typeAt
+		g.typeAt += "Value"
+		defer func() { g.typeAt = typeAt }()
+		return g.goType(ap.A), true
+	case ap.B:
+		return "interface{}", true
+	}
+	return "", false
+}
+
+// additionalProperties adds the catch-all field for the additional
+// properties of schema to the struct model m, whose fields hold the given
+// known properties; other properties are kept in it when unmarshaled and
+// added back when marshaled. owner names the schema in log messages.
+func (g *generator) additionalProperties(owner string, m *modelData, schema *base.Schema, known *orderedmap.Map[string, *base.SchemaProxy]) {
+	g.at, g.typeAt = owner, m.Name
+	typ, ok := g.additionalType(schema)
+	if !ok {
+		return
+	}
+	for _, f := range m.Fields {
+		if f.Name == additionalField {
+			g.log().Warn("property takes the name of the additional properties field, dropping them", "schema", owner)
+			return
+		}
+	}
+	m.Additional = typ
+	m.Known = nil
+	for name := range known.KeysFromOldest() {
+		m.Known = append(m.Known, name)
+	}
+}
@@ -0,0 +1,91 @@
+package apiClient
+
+import (
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// allOfExtension selects how an allOf schema is generated: "merge", the
+// default, flattens the properties of every member into one struct;
+// "embed" embeds the structs of referenced members instead.
+const allOfExtension = "x-go-allof"
+
+const (
+	allOfMerge = "merge"
+	allOfEmbed = "embed"
+)
+
+// allOfModel returns the struct model name for an allOf schema, or false
+// if schema has no allOf. owner names the schema in log messages.
+func (g *generator) allOfModel(owner, name string, schema *base.Schema) (modelData, bool) {
+	if len(schema.AllOf) == 0 {
+		return modelData{}, false
+	}
+	mode := allOfMerge
+	switch value := extension(schema, allOfExtension); value {
+	case "":
+	case allOfMerge, allOfEmbed:
+		mode = value
+	default:
+		g.log().Warn("invalid "+allOfExtension+", merging", "schema", owner, "value", value)
+	}
+
+	m := modelData{
+		Name:        name,
+		Description: schema.Description,
+		Example:     typeExample(schema),
+		Deprecated:  isDeprecated(schema.Deprecated),
+		Struct:      true,
+	}
+	props := orderedmap.New[string, *base.SchemaProxy]()
+	required := slices.Clone(schema.Required)
+	seen := map[*base.Schema]bool{schema: true}
+	// known also holds the properties of embedded structs.
+	known := orderedmap.New[string, *base.SchemaProxy]()
+	for _, member := range schema.AllOf {
+		if mode == allOfEmbed && member.IsReference() && isStruct(member.Schema()) && !g.isRawProxy(member) {
+			m.Fields = append(m.Fields, fieldData{Type: g.goType(member), Embedded: true})
+			collectProperties(known, new([]string), member.Schema(), map[*base.Schema]bool{})
+			continue
+		}
+		collectProperties(props, &required, member.Schema(), seen)
+	}
+	for propName, prop := range schema.Properties.FromOldest() {
+		props.Set(propName, prop)
+	}
+	m.Fields = append(m.Fields, g.structFields(owner, name, props, required)...)
+	g.uniqueFields(owner, m.Fields)
+	for propName, prop := range props.FromOldest() {
+		known.Set(propName, prop)
+	}
+	g.additionalProperties(owner, &m, schema, known)
+	m.Constructor = g.constructor(&m, props, required)
+	return m, true
+}
+
+// collectProperties adds the properties of schema, including those of its
+// own allOf members, to props, and its required properties to required. A
+// property defined more than once keeps its first position and its last
+// definition. seen guards against cycles.
+func collectProperties(props *orderedmap.Map[string, *base.SchemaProxy], required *[]string, schema *base.Schema, seen map[*base.Schema]bool) {
+	if schema == nil || seen[schema] {
+		return
+	}
+	seen[schema] = true
+	*required = append(*required, schema.Required...)
+	for _, member := range schema.AllOf {
+		collectProperties(props, required, member.Schema(), seen)
+	}
+	for propName, prop := range schema.Properties.FromOldest() {
+		props.Set(propName, prop)
+	}
+}
+
+// isStruct reports whether schema is generated as a plain struct, which can
+// be embedded.
+func isStruct(schema *base.Schema) bool {
+	return schema != nil && (len(schema.AllOf) > 0 || isObject(schema) && schema.Properties != nil) &&
+		len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && extension(schema, goTypeExtension) == "" && !isRaw(schema)
+}
@@ -0,0 +1,80 @@
+package apiClient
+
+import (
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// arrayFunc is the name of the generic function decoding the items of a
+// JSON array response one at a time, emitted alongside the client.
+const arrayFunc = "JSONArray"
+
+// buildItems adds to data, when Options.StreamArrays is set, the method
+// yielding the items of the first JSON array response of op that is not
+// an error, such as ListPetsItems, decoded one at a time as they are
+// received rather than into a slice.
+func (g *generator) buildItems(data *operationData, op *v3.Operation) {
+	if !g.opts.StreamArrays || op.Responses == nil {
+		return
+	}
+	var name, item string
+	for code, resp := range op.Responses.Codes.FromOldest() {
+		if isErrorStatus(code) {
+			continue
+		}
+		if name, item = g.arrayItem(data, code, resp); item != "" {
+			break
+		}
+	}
+	if item == "" && op.Responses.Default != nil {
+		name, item = g.arrayItem(data, "default", op.Responses.Default)
+	}
+	if item == "" {
+		return
+	}
+	if !g.usesArrays && g.models[arrayFunc] {
+		g.log().Warn("function name for JSON arrays is taken: no items method", "operation", data.Name, "function", arrayFunc)
+		return
+	}
+	g.usesArrays = true
+	g.models[arrayFunc] = true
+
+	data.Items = data.Name + "Items"
+	if free := freeName(data.Items, g.methods); free != data.Items {
+		g.renamed("operation", data.Method+" "+data.Path+" items", data.Items, free)
+		data.Items = free
+	}
+	g.methods[data.Items] = true
+	data.ItemsFunc, data.ItemType, data.ItemsMediaType = g.qualifier+arrayFunc, item, name
+}
+
+// arrayItem returns the JSON media type of the response code of resp and
+// the Go type of the items of its schema, or empty strings if it is not
+// an array. The items take the type they have in the field of the result,
+// so that inline objects are not declared twice.
+func (g *generator) arrayItem(data *operationData, code string, resp *v3.Response) (string, string) {
+	mt, ok := jsonContent(resp.Content)
+	if !ok || mt.Schema == nil {
+		return "", ""
+	}
+	schema := mt.Schema.Schema()
+	if schema == nil || schemaType(schema) != "array" || schema.Items == nil || !schema.Items.IsA() {
+		return "", ""
+	}
+	name := mediaTypeName(resp.Content, mt)
+	for _, r := range data.Responses {
+		if r.Code != code || r.Field == "" || r.Alternate {
+			continue
+		}
+		if item, ok := strings.CutPrefix(r.Type, "[]"); ok {
+			return name, item
+		}
+	}
+	if inlineObject(schema.Items.A) != nil {
+		// The items of a named array type are declared with it.
+		g.log().Warn("inline items of an array type: no items method", "operation", data.Name)
+		return "", ""
+	}
+	return name, g.goType(schema.Items.A)
+}
@@ -0,0 +1,71 @@
+package apiClient
+
+import "testing"
+
+const arraysSpec = `
+openapi: 3.0.3
+info: {title: arrays, version: "1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const arraysMain = `package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println(r.Header.Values("Accept"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "[{\"name\":\"a\",\"age\":1}, {\"name\":2}, {\"name\":\"c\"}]")
+	}))
+	defer srv.Close()
+	codec := client.NewCodec(json.Marshal, func(data []byte, v any) error {
+		fmt.Print("codec ")
+		return json.Unmarshal(data, v)
+	})
+	ctx := context.Background()
+	for _, opt := range []client.ClientOption{client.WithStrictDecoding(false), client.WithStrictDecoding(true), client.WithJSONCodec(codec)} {
+		c := client.NewClient(srv.URL, opt)
+		for pet, err := range c.ListPetsItems(ctx) {
+			fmt.Println(pet.Name, err != nil)
+		}
+	}
+}
+`
+
+// TestArrayItems checks that the items of a JSON array response are
+// decoded one by one with the codec of the client, as strictly as it is set
+// to, going on after an item that does not decode, and that the request
+// accepts JSON once.
+func TestArrayItems(t *testing.T) {
+	got := runGenerated(t, arraysSpec, arraysMain, func(o *Options) { o.StreamArrays = true })
+	want := "[application/json]\na false\n true\nc false\n" +
+		"[application/json]\na true\n true\nc false\n" +
+		"[application/json]\ncodec a false\ncodec  true\ncodec c false\n"
+	if got != want {
+		t.Errorf("items:\n%s\nwant:\n%s", got, want)
+	}
+}
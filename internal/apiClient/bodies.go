@@ -0,0 +1,660 @@
+package apiClient
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+const (
+	jsonMediaType   = "application/json"
+	binaryMediaType = "application/octet-stream"
+)
+
+// binaryFormat is the format of strings of raw bytes, such as files.
+const binaryFormat = "binary"
+
+// responseData describes how one documented status of an operation is
+// handled by the generated method.
+type responseData struct {
+	// Code is the status code, a range such as "2XX", or "default".
+	Code string
+	// Cond is the Go condition on resp.StatusCode selecting the
+	// response; empty for the default response.
+	Cond string
+	// Field is the field of the result the JSON body is decoded into, and
+	// Type its Go type; both are empty when the body is not decoded.
+	// Binary bodies are kept as they are, in a []byte field, and Text
+	// ones in a string field. Negotiated marks a body documented along
+	// with other media types, decoded or set only when the Content-Type
+	// of the response is its own: JSON, XML, or one of MediaTypes for
+	// Binary, Text and CSV bodies.
+	Field      string
+	Type       string
+	Binary     bool
+	Text       bool
+	Negotiated bool
+	MediaTypes []string
+	// XML marks a body documented as XML rather than JSON, decoded with
+	// encoding/xml into an XML field such as XML200, and CodecMediaType
+	// one of a codecMediaTypes, decoded by the Codec of the client for it
+	// into a field such as MsgPack200.
+	XML            bool
+	CodecMediaType string
+	// Alternates are the bodies of the status in other media types than
+	// that of the response, such as XML along with JSON, decoded or kept in
+	// fields of their own when the Content-Type of the response is theirs.
+	// buildResponses lists them as responses of their own, before it, and
+	// marks them Alternate.
+	Alternates []responseData
+	Alternate  bool
+	// CSV marks a text/csv body, decoded into a CSV field such as CSV200
+	// holding its rows of type Row.
+	CSV bool
+	Row string
+	// Error marks a status of 400 or above, which the method returns as a
+	// ResponseError holding the decoded body rather than as its result.
+	Error bool
+	// Download marks a Binary body that is not an error, which the
+	// Download method of the operation writes to an io.Writer.
+	Download bool
+	// SchemaKey is the key of the responseSchemas entry of the client
+	// that WithResponseValidation checks a JSON body against, such as
+	// "GET /pets/{id} 200".
+	SchemaKey string
+}
+
+// buildBodies sets the request type and the result of data from the
+// request body and the responses of op. The request body gets a type such
+// as CreatePetRequest, an alias of the component schema it refers to or
+// a struct for an inline object, and a method such as CreatePetWithBody
+// taking it as an io.Reader. The result type, such as
+// CreatePetResponse, has one field per documented status with a JSON body.
+func (g *generator) buildBodies(data *operationData, op *v3.Operation) error {
+	if rb := op.RequestBody; rb != nil && orderedmap.Len(rb.Content) > 0 {
+		typ, err := g.requestType(data, rb.Content)
+		if err != nil {
+			return err
+		}
+		data.RequestType = typ
+		_, isJSON := jsonContent(rb.Content)
+		if _, isForm := mediaTypeContent(rb.Content, formMediaType); isForm && !data.Multipart && data.StreamContentType == "" {
+			g.buildForm(data, isJSON)
+		}
+		data.WithBody = data.Name + "WithBody"
+		if free := freeName(data.WithBody, g.methods); free != data.WithBody {
+			g.renamed("operation", data.Method+" "+data.Path+" with body", data.WithBody, free)
+			data.WithBody = free
+		}
+		g.methods[data.WithBody] = true
+	}
+	return g.buildResponses(data, op)
+}
+
+// requestType returns the Go type of the JSON, form or XML request body
+// and declares it on data. Multipart forms get a struct of their parts,
+// binary content is streamed from an io.Reader, and other content is
+// represented by interface{} and sent as JSON.
+// Read-only properties are left out of the request type, which is
+// then a struct of its own rather than an alias of the model.
+func (g *generator) requestType(data *operationData, content *orderedmap.Map[string, *v3.MediaType]) (string, error) {
+	name := g.typeName(data.Name + "Request")
+	g.at, g.typeAt = name, name
+	mt, ok := jsonContent(content)
+	data.BodyMediaType = jsonMediaType
+	if ok {
+		data.BodyMediaType = mediaTypeName(content, mt)
+	}
+	if form, multipart := multipartContent(content); !ok && multipart {
+		if typ, ok := g.multipartType(data, name, form); ok {
+			return typ, nil
+		}
+		g.degraded("interface{}", "multipart request body is not an object")
+		return "interface{}", nil
+	}
+	if !ok {
+		// A form is typed as its JSON would be, and encoded by buildForm.
+		mt, ok = mediaTypeContent(content, formMediaType)
+	}
+	if xmlType, _, isXML := xmlContent(content); isXML && ok {
+		// An XML body along with those is sent by a variant.
+		g.buildXMLBody(data, xmlType)
+	} else if isXML {
+		// So is an XML body, encoded with encoding/xml.
+		data.XMLContentType, mt, ok = xmlContent(content)
+	}
+	if !ok {
+		// And one of a codecMediaTypes, encoded by the Codec of the client.
+		data.CodecContentType, mt, ok = codecContent(content)
+	}
+	if mediaType, binary := binaryContent(content); !ok && binary {
+		if strings.Contains(mediaType, "*") {
+			// A range such as image/*, which the caller may narrow down.
+			mediaType = binaryMediaType
+		}
+		data.StreamContentType = mediaType
+		return "io.Reader", nil
+	}
+	if !ok {
+		g.degraded("interface{}", "request body is "+firstMediaType(content)+", not JSON")
+		return "interface{}", nil
+	}
+	if mt.Schema == nil {
+		g.degraded("interface{}", "request body has no schema")
+		return "interface{}", nil
+	}
+
+	proxy := mt.Schema
+	if proxy.IsReference() && g.schemaTypeName(refName(proxy.GetReference())) == name {
+		// The schema already carries the name of the type.
+		return g.goType(proxy), nil
+	}
+	if free := freeName(name, g.models); free != name {
+		g.renamed("type", data.Name+" request body", name, free)
+		name = free
+		g.at, g.typeAt = name, name
+	}
+	m := modelData{Name: name, Description: "is the request body of " + data.Name + "."}
+	if schema := inlineObject(proxy); schema != nil {
+		props, required, _ := withoutProperties(schema, isReadOnly)
+		m.Struct = true
+		m.Fields = g.structFields(name, name, props, required)
+		if data.XMLContentType != "" {
+			m.XMLName = xmlRootName(schema, "")
+		}
+		g.additionalProperties(name, &m, schema, props)
+		m.Constructor = g.constructor(&m, props, required)
+	} else if props, required, ok := writableProperties(proxy); ok && !g.isRawProxy(proxy) {
+		// The model would send what only the server may set.
+		m.Description = "is the request body of " + data.Name + ": " + g.goType(proxy) + " without its read-only properties."
+		m.Struct = true
+		m.Fields = g.structFields(name, name, props, required)
+		if data.XMLContentType != "" {
+			// It is sent as the element of the schema it stands for.
+			m.XMLName = xmlRootName(proxy.Schema(), refName(proxy.GetReference()))
+		}
+		g.additionalProperties(name, &m, proxy.Schema(), props)
+		m.Constructor = g.constructor(&m, props, required)
+	} else if m.Type = g.goType(proxy); m.Type == name {
+		// An inline enum, union or allOf, declared by goType.
+		g.describePending(name, m.Description)
+		return name, nil
+	} else {
+		m.Alias = true
+	}
+	g.models[name] = true
+	data.Types = append(data.Types, m)
+	return name, nil
+}
+
+// buildResponses names the result type of data and collects its
+// responses: exact status codes in document order, then ranges, then the
+// default response. Statuses of 400 and above are listed even without a
+// JSON body, since they are returned as errors, and the default response
+// is listed twice: as the error of those statuses and the result of others.
+// Statuses without content are listed when a range or the default response
+// would otherwise decode their empty body, and so are 204 No Content and
+// 205 Reset Content when they are not documented.
+func (g *generator) buildResponses(data *operationData, op *v3.Operation) error {
+	data.Response = g.typeName(data.Name + "Response")
+	if wanted := data.Response; g.models[wanted] {
+		data.Response = g.typeName(data.Name + "Result")
+		if g.models[data.Response] {
+			data.Response = freeName(wanted, g.models)
+			g.renamed("type", data.Name+" result", wanted, data.Response)
+		}
+	}
+	g.models[data.Response] = true
+	if op.Responses == nil {
+		return nil
+	}
+	var accept []string
+	addAccept := func(r responseData, resp *v3.Response) {
+		for _, r := range append([]responseData{r}, r.Alternates...) {
+			for _, name := range acceptedMediaTypes(r, resp.Content) {
+				if !slices.Contains(accept, name) {
+					accept = append(accept, name)
+				}
+			}
+		}
+	}
+
+	var exact, ranges []responseData
+	for code, resp := range op.Responses.Codes.FromOldest() {
+		r, err := g.response(data, code, resp)
+		if err != nil {
+			return err
+		}
+		addAccept(r, resp)
+		r.Error = isErrorStatus(code)
+		switch {
+		case r.Field == "" && !r.Error && strings.HasSuffix(strings.ToUpper(code), "XX"):
+			// Nothing to decode, and not an error either.
+		case strings.HasSuffix(strings.ToUpper(code), "XX"):
+			ranges = append(ranges, withAlternates(r)...)
+		default:
+			exact = append(exact, withAlternates(r)...)
+		}
+	}
+	data.Responses = append(exact, ranges...)
+	if resp := op.Responses.Default; resp != nil {
+		data.HasDefault = true
+		r, err := g.response(data, "default", resp)
+		if err != nil {
+			return err
+		}
+		addAccept(r, resp)
+		// The default response of a status of 400 or above is an error,
+		// matched before the results of the others.
+		e := r
+		e.Cond, e.Error = "resp.StatusCode >= http.StatusBadRequest", true
+		data.Responses = append(data.Responses, withAlternates(e)...)
+		if r.Field != "" {
+			data.Responses = append(data.Responses, withAlternates(r)...)
+		}
+	}
+	data.Accept = strings.Join(accept, ", ")
+	g.noContent(data)
+	return nil
+}
+
+// withAlternates returns the Alternates of r, each selected by the
+// Content-Type of the response as well as the status of r, followed by r.
+func withAlternates(r responseData) []responseData {
+	var responses []responseData
+	for _, alt := range r.Alternates {
+		if r.Error && !alt.Decoded() {
+			// Kept in the Body of the ResponseError, as it is anyway.
+			continue
+		}
+		alt.Cond, alt.Error = alt.ContentTypeCheck(), r.Error
+		if r.Cond != "" {
+			alt.Cond = r.Cond + " && " + alt.Cond
+		}
+		// The condition checks the Content-Type already.
+		alt.Negotiated, alt.Alternate = false, true
+		responses = append(responses, alt)
+	}
+	r.Alternates = nil
+	return append(responses, r)
+}
+
+// ResultFields returns the responses of o that have a field in the result
+// type, each Alternate after the response it goes with.
+func (o operationData) ResultFields() []responseData {
+	var fields, alternates []responseData
+	for _, r := range o.Responses {
+		switch {
+		case r.Field == "" || r.Error:
+		case r.Alternate:
+			alternates = append(alternates, r)
+		default:
+			fields = append(append(fields, r), alternates...)
+			alternates = nil
+		}
+	}
+	return append(fields, alternates...)
+}
+
+// noContentCodes are the statuses whose responses have no body, whatever
+// content the document gives them.
+var noContentCodes = []string{"204", "205", "304"}
+
+// noContent drops the responses of data without content that no range or
+// default response would otherwise decode, and adds a case for the
+// undocumented 204 and 205 statuses before those that would, and for 304
+// of a GET, the answer to a conditional request.
+func (g *generator) noContent(data *operationData) {
+	decoded := func(r responseData) bool {
+		return !r.Error && r.Field != "" && !strings.HasPrefix(r.Cond, "resp.StatusCode == ")
+	}
+	i := slices.IndexFunc(data.Responses, decoded)
+	if i < 0 {
+		data.Responses = slices.DeleteFunc(data.Responses, func(r responseData) bool {
+			return r.Field == "" && !r.Error
+		})
+		return
+	}
+	codes := []string{"204", "205"}
+	if data.Method == http.MethodGet {
+		codes = append(codes, "304")
+	}
+	var conds []string
+	for _, code := range codes {
+		documented := slices.ContainsFunc(data.Responses, func(r responseData) bool { return r.Code == code })
+		// A range of statuses or the default response may match it.
+		matched := slices.ContainsFunc(data.Responses[i:], func(r responseData) bool {
+			return decoded(r) && (r.Cond == "" || r.Code == code[:1]+"XX")
+		})
+		if !documented && matched {
+			conds = append(conds, "resp.StatusCode == "+code)
+		}
+	}
+	if len(conds) > 0 {
+		data.Responses = slices.Insert(data.Responses, i, responseData{Code: "204", Cond: strings.Join(conds, " || ")})
+	}
+}
+
+// response returns how the response of op with the given status code is
+// handled, declaring a struct on data for an inline object body.
+func (g *generator) response(data *operationData, code string, resp *v3.Response) (responseData, error) {
+	r := responseData{Code: code}
+	switch upper := strings.ToUpper(code); {
+	case code == "default":
+	case len(upper) == 3 && strings.HasSuffix(upper, "XX"):
+		r.Code = upper
+		r.Cond = "resp.StatusCode/100 == " + upper[:1]
+	default:
+		if _, err := strconv.Atoi(code); err != nil {
+			return responseData{}, fmt.Errorf("invalid response status code %q", code)
+		}
+		r.Cond = "resp.StatusCode == " + code
+	}
+
+	if orderedmap.Len(resp.Content) > 0 && (slices.Contains(noContentCodes, code) || data.Method == http.MethodHead) {
+		g.log().Warn("content of a response without a body: left undecoded", "operation", data.Name, "status", code)
+		return r, nil
+	}
+
+	suffix := r.Code
+	if code == "default" {
+		suffix = "Default"
+	}
+	bodies := g.responseBodies(data, r, suffix, resp.Content)
+	if len(bodies) == 0 {
+		return r, nil
+	}
+	r, r.Alternates = bodies[0], bodies[1:]
+	return r, nil
+}
+
+// responseBodies returns the bodies of r, documented by content, that the
+// client decodes or keeps, one per kind of media type and in this order of
+// precedence: JSON, binary, XML, one of a codecMediaTypes, CSV rows and
+// text. Their fields are named after suffix, and so are the types of the
+// first, while those of the others are named after their kind as well.
+func (g *generator) responseBodies(data *operationData, r responseData, suffix string, content *orderedmap.Map[string, *v3.MediaType]) []responseData {
+	var bodies []responseData
+	typeSuffix := func(kind string) string {
+		if len(bodies) == 0 {
+			return suffix
+		}
+		return suffix + kind
+	}
+	negotiated := orderedmap.Len(content) > 1
+	if mt, ok := jsonContent(content); ok {
+		b := r
+		b.Field, b.Negotiated = "JSON"+suffix, negotiated
+		if mt.Schema != nil {
+			b.SchemaKey = data.Method + " " + data.Path + " " + r.Code
+			g.addResponseSchema(b.SchemaKey, mt.Schema)
+		}
+		var problem string
+		if (r.Code == "default" || isErrorStatus(r.Code)) && isProblem(content) {
+			g.at = data.Name + " " + r.Code + " response"
+			problem, _ = g.problemType()
+		}
+		if problem != "" {
+			b.Type = "*" + problem
+		} else {
+			b = g.bodyType(data, b, suffix, mt)
+		}
+		bodies = append(bodies, b)
+	}
+	if names := mediaTypes(content, isBinary); len(names) > 0 {
+		b := r
+		b.Field, b.Type, b.Binary = "Binary"+suffix, "[]byte", true
+		g.negotiate(&b, content, names)
+		bodies = append(bodies, b)
+	}
+	// An XML array, which encoding/xml cannot decode, is only reported when
+	// there is no other body.
+	if _, mt, ok := xmlContent(content); ok && (len(bodies) == 0 || !isXMLArray(mt)) {
+		b := r
+		b.Field, b.XML, b.Negotiated = "XML"+suffix, true, negotiated
+		if b = g.bodyType(data, b, typeSuffix("XML"), mt); b.Field != "" {
+			bodies = append(bodies, b)
+		}
+	}
+	if mediaType, mt, ok := codecContent(content); ok {
+		b := r
+		b.Field, b.CodecMediaType = codecMediaTypes[mediaType]+suffix, mediaType
+		g.negotiate(&b, content, []string{mediaType})
+		bodies = append(bodies, g.bodyType(data, b, typeSuffix(codecMediaTypes[mediaType]), mt))
+	}
+	csv := r
+	if g.csvResponse(data, &csv, suffix, content) {
+		bodies = append(bodies, csv)
+	}
+	// CSV bodies not decoded as rows are kept as text.
+	if names := mediaTypes(content, func(name string, mt *v3.MediaType) bool {
+		return isText(name, mt) && !(csv.CSV && isCSV(name, mt))
+	}); len(names) > 0 {
+		b := r
+		b.Field, b.Type, b.Text = "Text"+suffix, "string", true
+		g.negotiate(&b, content, names)
+		bodies = append(bodies, b)
+	}
+	return bodies
+}
+
+// bodyType returns r with the Go type of its body, of the media type mt,
+// declaring a struct on data named after suffix for an inline object, and
+// for a model with write-only properties, which are left out. An XML body
+// that encoding/xml cannot decode is left undecoded.
+func (g *generator) bodyType(data *operationData, r responseData, suffix string, mt *v3.MediaType) responseData {
+	name := g.typeName(data.Name + "Response" + suffix)
+	g.at, g.typeAt = name, name
+	if mt.Schema == nil {
+		g.degraded("interface{}", r.Code+" response has no schema")
+		r.Type = "interface{}"
+		return r
+	}
+	schema := inlineObject(mt.Schema)
+	props, required, readable := readableProperties(mt.Schema)
+	if schema != nil || readable && !g.isRawProxy(mt.Schema) {
+		if free := freeName(name, g.models); free != name {
+			g.renamed("type", data.Name+" "+r.Code+" response", name, free)
+			name = free
+			g.at, g.typeAt = name, name
+		}
+		g.models[name] = true
+		m := modelData{
+			Name:        name,
+			Description: "is the " + r.Code + " response of " + data.Name + ".",
+			Struct:      true,
+		}
+		root := ""
+		if schema == nil {
+			// The model would hold what only the client may send.
+			m.Description = "is the " + r.Code + " response of " + data.Name + ": " + g.goType(mt.Schema) + " without its write-only properties."
+			schema, root = mt.Schema.Schema(), refName(mt.Schema.GetReference())
+		} else {
+			props, required, _ = withoutProperties(schema, isWriteOnly)
+		}
+		m.Fields = g.structFields(name, name, props, required)
+		if r.XML {
+			m.XMLName = xmlRootName(schema, root)
+		}
+		g.additionalProperties(name, &m, schema, props)
+		data.Types = append(data.Types, m)
+		r.Type = "*" + name
+		return r
+	}
+	r.Type = g.goType(mt.Schema)
+	g.describePending(r.Type, "is the "+r.Code+" response of "+data.Name+".")
+	if r.XML && (strings.HasPrefix(r.Type, "[]") || strings.HasPrefix(r.Type, "map[") || r.Type == "interface{}") {
+		// encoding/xml decodes a single root element.
+		g.degraded("", r.Code+" response is XML but not an object")
+		r.Field, r.Type, r.XML = "", "", false
+		return r
+	}
+	if !isNilable(r.Type) {
+		r.Type = "*" + r.Type
+	}
+	return r
+}
+
+// isErrorStatus reports whether the status code or range code, such as
+// "404" or "5XX", is 400 or above.
+func isErrorStatus(code string) bool {
+	return strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5")
+}
+
+// acceptedMediaTypes returns the media types of content, that of the
+// response r, which the Accept header asks for, in document order: those
+// the body is decoded or kept under when documented along with others, such
+// as JSON but not XML, or else all of them.
+func acceptedMediaTypes(r responseData, content *orderedmap.Map[string, *v3.MediaType]) []string {
+	match := func(string) bool { return true }
+	switch {
+	case r.Field == "" || !r.Negotiated:
+	case len(r.MediaTypes) > 0:
+		match = func(name string) bool { return slices.Contains(r.MediaTypes, name) }
+	case r.XML:
+		match = isXMLMediaType
+	default:
+		match = func(name string) bool { return name == jsonMediaType || strings.HasSuffix(name, "+json") }
+	}
+	var names []string
+	for name := range content.KeysFromOldest() {
+		if mediaType, _, _ := strings.Cut(name, ";"); match(strings.TrimSpace(mediaType)) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// writableProperties returns the properties of the struct schema proxy
+// refers to that are not read-only, and the required ones among them, or
+// false if it has no read-only property.
+func writableProperties(proxy *base.SchemaProxy) (*orderedmap.Map[string, *base.SchemaProxy], []string, bool) {
+	if !proxy.IsReference() || !isStruct(proxy.Schema()) {
+		return nil, nil, false
+	}
+	return withoutProperties(proxy.Schema(), isReadOnly)
+}
+
+// readableProperties is writableProperties for responses, leaving out the
+// write-only properties.
+func readableProperties(proxy *base.SchemaProxy) (*orderedmap.Map[string, *base.SchemaProxy], []string, bool) {
+	if !proxy.IsReference() || !isStruct(proxy.Schema()) {
+		return nil, nil, false
+	}
+	return withoutProperties(proxy.Schema(), isWriteOnly)
+}
+
+// inlineObject returns the schema of proxy if it is an inline object with
+// properties, which is generated as a struct of its own.
+func inlineObject(proxy *base.SchemaProxy) *base.Schema {
+	if proxy.IsReference() {
+		return nil
+	}
+	schema := proxy.Schema()
+	if schema == nil || !isObject(schema) || orderedmap.Len(schema.Properties) == 0 || len(schema.AllOf) > 0 ||
+		extension(schema, goTypeExtension) != "" || isRaw(schema) {
+		return nil
+	}
+	return schema
+}
+
+// isNilable reports whether the Go type expression typ already has a nil
+// value, so that a field of it need not be a pointer.
+func isNilable(typ string) bool {
+	return typ == "interface{}" || typ == rawJSONType || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || strings.HasPrefix(typ, "*")
+}
+
+// mediaTypeName returns the name under which content documents mt.
+func mediaTypeName(content *orderedmap.Map[string, *v3.MediaType], mt *v3.MediaType) string {
+	for name, m := range content.FromOldest() {
+		if m == mt {
+			return name
+		}
+	}
+	return ""
+}
+
+func firstMediaType(content *orderedmap.Map[string, *v3.MediaType]) string {
+	for name := range content.KeysFromOldest() {
+		return name
+	}
+	return ""
+}
+
+// jsonContent returns the application/json media type of content, or
+// else the first one with a JSON suffix such as application/problem+json.
+func jsonContent(content *orderedmap.Map[string, *v3.MediaType]) (*v3.MediaType, bool) {
+	if content == nil {
+		return nil, false
+	}
+	if mt, ok := content.Get(jsonMediaType); ok {
+		return mt, true
+	}
+	for name, mt := range content.FromOldest() {
+		name, _, _ = strings.Cut(name, ";")
+		if strings.HasSuffix(strings.TrimSpace(name), "+json") || strings.TrimSpace(name) == jsonMediaType {
+			return mt, true
+		}
+	}
+	return nil, false
+}
+
+// binaryContent returns the first media type of content holding raw bytes,
+// as isBinary has it.
+func binaryContent(content *orderedmap.Map[string, *v3.MediaType]) (string, bool) {
+	for name, mt := range content.FromOldest() {
+		if isBinary(name, mt) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// isBinary reports whether the media type name of content mt holds raw
+// bytes: a string of the binary format or, as OpenAPI 3.1 has it, an
+// application/octet-stream without a schema or with a string one.
+func isBinary(name string, mt *v3.MediaType) bool {
+	var schema *base.Schema
+	if mt.Schema != nil {
+		if schema = mt.Schema.Schema(); schema == nil {
+			return false
+		}
+	}
+	if mediaType, _, _ := strings.Cut(name, ";"); strings.TrimSpace(mediaType) == binaryMediaType {
+		return schema == nil || schemaType(schema) == "string"
+	}
+	return schema != nil && schemaType(schema) == "string" && schema.Format == binaryFormat
+}
+
+// bodySchemas returns the JSON and multipart schemas of the request body
+// and the responses of op, the ones buildBodies generates types from.
+func bodySchemas(op *v3.Operation) []*base.SchemaProxy {
+	var schemas []*base.SchemaProxy
+	add := func(content *orderedmap.Map[string, *v3.MediaType]) {
+		mt, ok := jsonContent(content)
+		if !ok {
+			mt, ok = multipartContent(content)
+		}
+		if ok && mt.Schema != nil {
+			schemas = append(schemas, mt.Schema)
+		}
+	}
+	if rb := op.RequestBody; rb != nil {
+		add(rb.Content)
+	}
+	if op.Responses != nil {
+		for _, resp := range op.Responses.Codes.FromOldest() {
+			add(resp.Content)
+		}
+		if op.Responses.Default != nil {
+			add(op.Responses.Default.Content)
+		}
+	}
+	return schemas
+}
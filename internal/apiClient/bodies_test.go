@@ -0,0 +1,270 @@
+package apiClient
+
+import (
+	"strings"
+	"testing"
+)
+
+const acceptSpec = `
+openapi: 3.0.3
+info: {title: accept, version: "1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pets'}
+            application/xml:
+              schema: {$ref: '#/components/schemas/Pets'}
+            text/html: {}
+        default:
+          description: error
+          content:
+            application/problem+json: {}
+components:
+  schemas:
+    Pets:
+      type: object
+      properties:
+        names: {type: array, items: {type: string}}
+`
+
+// TestAcceptHeader checks that requests only ask for the media types the
+// client decodes.
+func TestAcceptHeader(t *testing.T) {
+	src := string(generateFile(t, acceptSpec))
+	want := `req.Header.Set("Accept", "application/json, application/xml, application/problem+json")`
+	if !strings.Contains(src, want) {
+		t.Errorf("ListPets does not set %s", want)
+	}
+}
+
+const negotiationSpec = `
+openapi: 3.0.3
+info: {title: negotiation, version: "1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+            application/xml:
+              schema: {$ref: '#/components/schemas/Pet'}
+            text/csv:
+              schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+            text/plain: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const negotiationMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+var bodies = map[string]string{
+	"application/json": "{\"name\":\"json\"}",
+	"application/xml":  "<Pet><name>xml</name></Pet>",
+	"text/csv":         "name\ncsv\n",
+	"text/plain":       "text",
+	"text/html":        "<p>html</p>",
+}
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept := r.Header.Get("Accept")
+		body, ok := bodies[accept]
+		if !ok {
+			fmt.Println(accept)
+			accept, body = "application/json", bodies["application/json"]
+		}
+		w.Header().Set("Content-Type", accept)
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL)
+	ctx := context.Background()
+	for _, accept := range []string{"", "application/json", "application/xml", "text/csv", "text/plain", "text/html"} {
+		var opts []client.RequestOption
+		if accept != "" {
+			opts = append(opts, client.WithAccept(accept))
+		}
+		resp, err := c.ListPets(ctx, opts...)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		var fields []string
+		if resp.JSON200 != nil {
+			fields = append(fields, "json "+resp.JSON200.Name)
+		}
+		if resp.XML200 != nil {
+			fields = append(fields, "xml "+resp.XML200.Name)
+		}
+		if resp.CSV200 != nil {
+			fields = append(fields, "csv "+resp.CSV200[0].Name)
+		}
+		if resp.Text200 != "" {
+			fields = append(fields, "text "+resp.Text200)
+		}
+		fmt.Println(accept, fields, len(resp.Body) > 0)
+	}
+}
+`
+
+// TestContentNegotiation checks that requests ask for every media type of
+// the response the client decodes, and that the body is decoded into the
+// field of its Content-Type only.
+func TestContentNegotiation(t *testing.T) {
+	got := runGenerated(t, negotiationSpec, negotiationMain)
+	want := `application/json, application/xml, text/csv, text/plain
+ [json json] true
+application/json [json json] true
+application/xml [xml xml] true
+text/csv [csv csv] true
+text/plain [text text] true
+text/html [] true
+`
+	if got != want {
+		t.Errorf("results:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+const jsonContentTypeSpec = `
+openapi: 3.0.3
+info: {title: json content type, version: "1"}
+paths:
+  /pets/{id}:
+    patch:
+      operationId: patchPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      requestBody:
+        required: true
+        content:
+          application/merge-patch+json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        "204": {description: ok}
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/vnd.pets+json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        "204": {description: ok}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const jsonContentTypeMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println(r.Method, r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL)
+	ctx := context.Background()
+	c.PatchPet(ctx, client.PatchPetParams{ID: "1"}, client.Pet{Name: "x"})
+	c.CreatePet(ctx, client.Pet{Name: "x"})
+}
+`
+
+// TestJSONContentType checks that a JSON body is sent with the media type
+// the document gives it.
+func TestJSONContentType(t *testing.T) {
+	got := runGenerated(t, jsonContentTypeSpec, jsonContentTypeMain)
+	want := "PATCH application/merge-patch+json\nPOST application/vnd.pets+json\n"
+	if got != want {
+		t.Errorf("content types:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestDirectionalStructs checks that a model with read-only and write-only
+// properties gets request and response structs without them, which keep
+// the enum type and the additional properties of the model.
+func TestDirectionalStructs(t *testing.T) {
+	src := string(generateFile(t, `
+openapi: 3.0.3
+info: {title: directional, version: "1"}
+paths:
+  /widgets:
+    post:
+      operationId: createWidget
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Widget'}
+      responses:
+        "201":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Widget'}
+components:
+  schemas:
+    Widget:
+      type: object
+      additionalProperties: {type: string}
+      properties:
+        id: {type: string, readOnly: true}
+        password: {type: string, writeOnly: true}
+        kind: {type: string, enum: [a, b]}
+`))
+	for typ, dropped := range map[string]string{"CreateWidgetRequest": "ID ", "CreateWidgetResponse201": "Password "} {
+		start := strings.Index(src, "type "+typ+" struct {")
+		if start < 0 {
+			t.Errorf("no struct %s:\n%s", typ, src)
+			continue
+		}
+		decl := src[start : start+strings.Index(src[start:], "\n}")]
+		if strings.Contains(decl, dropped) {
+			t.Errorf("%s has the field %s:\n%s", typ, dropped, decl)
+		}
+		for _, field := range []string{"WidgetKind `", "AdditionalProperties map[string]string"} {
+			if !strings.Contains(decl, field) {
+				t.Errorf("%s has no %s field:\n%s", typ, field, decl)
+			}
+		}
+	}
+	if strings.Contains(src, "CreateWidgetRequestKind") || strings.Contains(src, "CreateWidgetResponse201Kind") {
+		t.Errorf("enum of Widget declared again:\n%s", src)
+	}
+}
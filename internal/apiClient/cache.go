@@ -0,0 +1,182 @@
+package apiClient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// buildCache remembers, for every file of an output directory, a hash of
+// the inputs it was rendered from and of the content written. A file whose
+// inputs are unchanged and whose copy on disk is still the one written is
+// reused instead of being rendered again.
+//
+// The cache lives in the user cache directory, so output directories stay
+// clean. Inputs cover the template data of the file, the templates and the
+// oasgen binary itself; post passes are not covered, so the cache is not
+// used while any is registered.
+type buildCache struct {
+	dir     string
+	salt    []byte
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// openCache returns the cache of the output directory dir, or nil when
+// caching is unavailable.
+func openCache(dir, templatesDir string) *buildCache {
+	if _, post := registeredPasses(); len(post) > 0 {
+		return nil
+	}
+	exe := executableHash()
+	if exe == nil {
+		return nil
+	}
+	salt := sha256.New()
+	salt.Write(exe)
+	if err := hashTemplates(salt, templatesDir); err != nil {
+		return nil
+	}
+
+	c := &buildCache{dir: dir, salt: salt.Sum(nil), entries: map[string]cacheEntry{}}
+	if path, ok := cachePath(dir); ok {
+		if data, err := os.ReadFile(path); err == nil {
+			// A corrupt cache is as good as an empty one.
+			_ = json.Unmarshal(data, &c.entries)
+		}
+	}
+	return c
+}
+
+// inputHash returns the hash of everything the file name is rendered from.
+func (c *buildCache) inputHash(name string, data fileData, imports []string) string {
+	h := sha256.New()
+	h.Write(c.salt)
+	enc := json.NewEncoder(h)
+	// fileData holds plain values only, so encoding cannot fail.
+	_ = enc.Encode(name)
+	_ = enc.Encode(data)
+	_ = enc.Encode(data.imports)
+	_ = enc.Encode(imports)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lookup returns the content of the file name on disk if it was rendered
+// from input and has not been modified since.
+func (c *buildCache) lookup(name, input string) ([]byte, bool) {
+	e, ok := c.entries[name]
+	if !ok || e.Input != input {
+		return nil, false
+	}
+	content, err := os.ReadFile(filepath.Join(c.dir, filepath.FromSlash(name)))
+	if err != nil || contentHash(content) != e.Output {
+		return nil, false
+	}
+	return content, true
+}
+
+// saveCache records the inputs of the files just written to dir. Files
+// without an input hash are not cached.
+func saveCache(dir string, files []File) error {
+	path, ok := cachePath(dir)
+	if !ok {
+		return nil
+	}
+	entries := map[string]cacheEntry{}
+	for _, f := range files {
+		if f.inputHash != "" {
+			entries[f.Name] = cacheEntry{Input: f.inputHash, Output: contentHash(f.Content)}
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// cachePath returns the file holding the cache of the output directory dir.
+func cachePath(dir string) (string, bool) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", false
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(base, "oasgen", hex.EncodeToString(sum[:8])+".json"), true
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashTemplates writes the default templates and the overrides in dir, if
+// any, to h.
+func hashTemplates(h io.Writer, dir string) error {
+	err := fs.WalkDir(defaultTemplates, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := defaultTemplates.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", path, data)
+		return nil
+	})
+	if err != nil || dir == "" {
+		return err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", filepath.Base(path), data)
+	}
+	return nil
+}
+
+// executableHash returns the hash of the running binary, so that a new
+// version of the generator invalidates the cache, or nil if it cannot be
+// read.
+var executableHash = sync.OnceValue(func() []byte {
+	path, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil
+	}
+	return h.Sum(nil)
+})
@@ -0,0 +1,112 @@
+package apiClient
+
+import "testing"
+
+const cacheSpec = `
+openapi: 3.0.3
+info: {title: cache, version: "1"}
+paths:
+  /pets/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+    put:
+      operationId: putPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const cacheMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println("server", r.Method, r.URL.Path, r.Header.Get("If-None-Match"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "max-age=60")
+		if r.URL.Path == "/pets/stale" {
+			w.Header().Set("Cache-Control", "max-age=0")
+			w.Header().Set("ETag", "\"v1\"")
+			if r.Header.Get("If-None-Match") == "\"v1\"" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		fmt.Fprint(w, "{\"name\":\"a\"}")
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL, client.WithCache(client.NewMemoryCache(10)))
+	ctx := context.Background()
+	get := func(id string) {
+		resp, err := c.GetPet(ctx, client.GetPetParams{ID: id})
+		if err != nil {
+			fmt.Println("error", err)
+			return
+		}
+		fmt.Println("client", resp.StatusCode(), resp.JSON200.Name)
+	}
+	put := func(id string) {
+		resp, err := c.PutPet(ctx, client.PutPetParams{ID: id}, client.Pet{Name: "a"})
+		if err != nil {
+			fmt.Println("error", err)
+			return
+		}
+		fmt.Println("client", resp.StatusCode(), resp.JSON200.Name)
+	}
+	get("fresh")
+	get("fresh")
+	get("stale")
+	get("stale")
+	put("fresh")
+	put("fresh")
+	get("fresh")
+}
+`
+
+// TestResponseCache checks that a fresh cached response answers a GET
+// without sending it, that a stale one is revalidated with its ETag and
+// answers the 304 that follows, and that other methods are sent every time
+// and remove the response of their URL.
+func TestResponseCache(t *testing.T) {
+	got := runGenerated(t, cacheSpec, cacheMain)
+	want := "server GET /pets/fresh \nclient 200 a\n" +
+		"client 200 a\n" +
+		"server GET /pets/stale \nclient 200 a\n" +
+		"server GET /pets/stale \"v1\"\nclient 200 a\n" +
+		"server PUT /pets/fresh \nclient 200 a\n" +
+		"server PUT /pets/fresh \nclient 200 a\n" +
+		"server GET /pets/fresh \nclient 200 a\n"
+	if got != want {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, want)
+	}
+}
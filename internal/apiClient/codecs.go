@@ -0,0 +1,61 @@
+package apiClient
+
+import (
+	"strconv"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// codecMediaTypes are the binary encodings of JSON data that bodies may be
+// documented in, each with the prefix of the result fields holding them.
+// The generated code leaves them to a Codec the client is given for the
+// media type, such as one of github.com/vmihailenco/msgpack or
+// github.com/fxamacker/cbor.
+var codecMediaTypes = map[string]string{
+	"application/msgpack":     "MsgPack",
+	"application/x-msgpack":   "MsgPack",
+	"application/vnd.msgpack": "MsgPack",
+	"application/cbor":        "CBOR",
+}
+
+// codecNames are the identifiers of the client option registering a Codec
+// for a media type, which schemas give way to when the document has bodies
+// of a codecMediaTypes.
+var codecNames = []string{"WithCodec"}
+
+// codecContent returns the first media type of content in codecMediaTypes,
+// without its parameters.
+func codecContent(content *orderedmap.Map[string, *v3.MediaType]) (string, *v3.MediaType, bool) {
+	for name, mt := range content.FromOldest() {
+		name, _, _ = strings.Cut(name, ";")
+		if name = strings.TrimSpace(name); codecMediaTypes[name] != "" {
+			return name, mt, true
+		}
+	}
+	return "", nil, false
+}
+
+// documentUsesCodecs reports whether a request body or response of an
+// operation of doc is of a codecMediaTypes, so that the client gets the
+// WithCodec option.
+func documentUsesCodecs(doc *v3.Document) bool {
+	return documentHasContent(doc, func(content *orderedmap.Map[string, *v3.MediaType]) bool {
+		_, _, ok := codecContent(content)
+		return ok
+	})
+}
+
+// Unmarshal returns the function decoding the body of r: the decodeJSON
+// method of the client, xml.Unmarshal or the Codec of the client for its
+// CodecMediaType.
+func (r responseData) Unmarshal() string {
+	switch {
+	case r.XML:
+		return "xml.Unmarshal"
+	case r.CodecMediaType != "":
+		return "c.codec(" + strconv.Quote(r.CodecMediaType) + ").Unmarshal"
+	}
+	return "c.decodeJSON"
+}
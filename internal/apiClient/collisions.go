@@ -0,0 +1,81 @@
+package apiClient
+
+import (
+	"strconv"
+	"strings"
+)
+
+// reservedNames returns the package-level identifiers of the generated code
+// itself, which schemas give way to: the client type, the provenance
+// constants, the ResponseError type, the client and request options, the
+// helper types of the options, the Codec type and the options of the
+// security schemes.
+func (g *generator) reservedNames() []string {
+	names := []string{g.opts.ClientName, "GeneratorVersion", "SpecTitle", "SpecVersion", "SpecHash", "UserAgent", responseError}
+	names = append(names, requestOptionNames...)
+	names = append(names, clientOptionNames(g.opts.ClientName)...)
+	names = append(names, g.opts.Optional.helperTypes()...)
+	if g.validates() {
+		names = append(names, validationError, violation)
+	}
+	if g.codecs {
+		names = append(names, codecNames...)
+	}
+	for _, s := range g.security {
+		names = append(names, s.Option)
+	}
+	return names
+}
+
+// freeName returns name if no generated type takes it, or else the first
+// free name with a number appended, such as Pet2.
+func freeName(name string, taken map[string]bool) string {
+	if !taken[name] {
+		return name
+	}
+	free := name
+	for i := 2; taken[free]; i++ {
+		free = name + strconv.Itoa(i)
+	}
+	return free
+}
+
+// renamed reports that the identifier wanted for the element of the
+// document at path, of the given kind, was taken or invalid, so that got is
+// generated instead.
+func (g *generator) renamed(kind, path, wanted, got string) {
+	g.log().Warn("identifier renamed", "kind", kind, "name", path, "wanted", wanted, "got", got)
+	if r := g.opts.Report; r != nil {
+		r.Renames = append(r.Renames, RenameReport{Kind: kind, Name: path, Wanted: wanted, Got: got})
+		r.Summary.Renamed++
+	}
+}
+
+// uniqueFields renames the fields of the struct of the schema owner whose
+// names are empty, repeat an earlier field, such as UserID for both user_id
+// and userId, or take the name of a generated method. Embedded fields keep
+// their names, which are those of their types.
+func (g *generator) uniqueFields(owner string, fields []fieldData) {
+	taken := map[string]bool{}
+	if g.validates() {
+		taken["Validate"] = true
+	}
+	for i := range fields {
+		f := &fields[i]
+		if f.Embedded {
+			name := strings.TrimPrefix(f.Type, "*")
+			taken[name[strings.LastIndex(name, ".")+1:]] = true
+			continue
+		}
+		name := f.Name
+		if name == "" {
+			name = "Field"
+		}
+		name = freeName(name, taken)
+		if name != f.Name {
+			g.renamed("property", owner+"."+f.JSONName, f.Name, name)
+			f.Name = name
+		}
+		taken[name] = true
+	}
+}
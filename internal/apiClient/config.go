@@ -0,0 +1,214 @@
+package apiClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ConfigFileNames lists the file names looked up, in order, when no config
+// file is given explicitly.
+var ConfigFileNames = []string{"oasgen.yaml", "oasgen.yml", "oasgen.json"}
+
+// Config is the on-disk representation of a generation run, typically
+// checked in as oasgen.yaml next to the spec.
+type Config struct {
+	Spec string `json:"spec" yaml:"spec"`
+	// MergeSpecs lists further documents merged into Spec.
+	MergeSpecs []string `json:"mergeSpecs" yaml:"mergeSpecs"`
+	Output     string   `json:"output" yaml:"output"`
+	// Module is the path of a standalone module to scaffold, see
+	// Options.Module.
+	Module string `json:"module" yaml:"module"`
+	Layout Layout `json:"layout" yaml:"layout"`
+	// ImportPath is the import path of the output directory, see
+	// Options.ImportPath.
+	ImportPath string `json:"importPath" yaml:"importPath"`
+	Package    string `json:"package" yaml:"package"`
+	ClientName string `json:"clientName" yaml:"clientName"`
+	// TemplatesDir holds *.tmpl files overriding the default templates.
+	TemplatesDir string `json:"templatesDir" yaml:"templatesDir"`
+	// HeaderFile is put at the top of every generated Go file.
+	HeaderFile string `json:"headerFile" yaml:"headerFile"`
+	// TypeMappings maps "type" or "type/format" to a Go type, see
+	// Options.TypeMappings.
+	TypeMappings map[string]string `json:"typeMappings" yaml:"typeMappings"`
+	Filter       Filter            `json:"filter" yaml:"filter"`
+	// PostHooks are commands run after writing, see RunPostHooks.
+	PostHooks []string `json:"postHooks" yaml:"postHooks"`
+	// Deprecated is "mark" or "skip", see DeprecatedPolicy.
+	Deprecated DeprecatedPolicy `json:"deprecated" yaml:"deprecated"`
+	Naming     Naming           `json:"naming" yaml:"naming"`
+	// LenientEnums accepts unknown enum values, see Options.LenientEnums.
+	LenientEnums bool `json:"lenientEnums" yaml:"lenientEnums"`
+	// Optional is "value", "pointer", "optional" or "sql", see
+	// OptionalStrategy.
+	Optional OptionalStrategy `json:"optional" yaml:"optional"`
+	// StructTags lists extra struct tags, see Options.StructTags.
+	StructTags []string `json:"structTags" yaml:"structTags"`
+	// Validate and ValidateRequests generate Validate methods, see
+	// Options.Validate.
+	Validate         bool `json:"validate" yaml:"validate"`
+	ValidateRequests bool `json:"validateRequests" yaml:"validateRequests"`
+	// RawJSON lists schemas and properties kept as raw JSON, see
+	// Options.RawJSON.
+	RawJSON []string `json:"rawJSON" yaml:"rawJSON"`
+	// Timeouts maps operationIds to timeouts, see Options.Timeouts.
+	Timeouts map[string]string `json:"timeouts" yaml:"timeouts"`
+	// StreamArrays adds the Items methods, see Options.StreamArrays.
+	StreamArrays bool `json:"streamArrays" yaml:"streamArrays"`
+}
+
+// FindConfig returns the first of ConfigFileNames present in dir, or an
+// empty string if there is none.
+func FindConfig(dir string) string {
+	for _, name := range ConfigFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// LoadConfig reads a YAML or JSON config file. Relative paths inside it are
+// resolved against the directory of the file.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("reading config: %w", err)
+	}
+
+	if filepath.Ext(path) == ".json" {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&cfg)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	cfg.Spec = resolvePath(dir, cfg.Spec)
+	for i, p := range cfg.MergeSpecs {
+		cfg.MergeSpecs[i] = resolvePath(dir, p)
+	}
+	cfg.Output = resolvePath(dir, cfg.Output)
+	cfg.TemplatesDir = resolvePath(dir, cfg.TemplatesDir)
+	cfg.HeaderFile = resolvePath(dir, cfg.HeaderFile)
+	return cfg, nil
+}
+
+// Apply copies every field set in the config onto opts.
+func (c Config) Apply(opts *Options) {
+	if c.Spec != "" {
+		opts.SpecPath = c.Spec
+	}
+	if len(c.MergeSpecs) > 0 {
+		opts.MergeSpecPaths = c.MergeSpecs
+	}
+	if c.Output != "" {
+		opts.OutPath = c.Output
+	}
+	if c.Module != "" {
+		opts.Module = c.Module
+	}
+	if c.Layout != "" {
+		opts.Layout = c.Layout
+	}
+	if c.ImportPath != "" {
+		opts.ImportPath = c.ImportPath
+	}
+	if c.Package != "" {
+		opts.PackageName = c.Package
+	}
+	if c.ClientName != "" {
+		opts.ClientName = c.ClientName
+	}
+	if c.TemplatesDir != "" {
+		opts.TemplatesDir = c.TemplatesDir
+	}
+	if c.HeaderFile != "" {
+		opts.HeaderFile = c.HeaderFile
+	}
+	if len(c.TypeMappings) > 0 {
+		if opts.TypeMappings == nil {
+			opts.TypeMappings = make(map[string]string, len(c.TypeMappings))
+		}
+		for k, v := range c.TypeMappings {
+			opts.TypeMappings[k] = v
+		}
+	}
+	opts.Filter = opts.Filter.merge(c.Filter)
+	if c.Deprecated != "" {
+		opts.Deprecated = c.Deprecated
+	}
+	if len(c.PostHooks) > 0 {
+		opts.PostHooks = c.PostHooks
+	}
+	if c.Naming.MethodNames != "" {
+		opts.Naming.MethodNames = c.Naming.MethodNames
+	}
+	if c.Naming.TypePrefix != "" {
+		opts.Naming.TypePrefix = c.Naming.TypePrefix
+	}
+	if len(c.Naming.Rename) > 0 {
+		if opts.Naming.Rename == nil {
+			opts.Naming.Rename = make(map[string]string, len(c.Naming.Rename))
+		}
+		for k, v := range c.Naming.Rename {
+			opts.Naming.Rename[k] = v
+		}
+	}
+	if c.Naming.Initialisms != nil {
+		opts.Naming.Initialisms = c.Naming.Initialisms
+	}
+	if c.LenientEnums {
+		opts.LenientEnums = true
+	}
+	if c.Optional != "" {
+		opts.Optional = c.Optional
+	}
+	if len(c.StructTags) > 0 {
+		opts.StructTags = c.StructTags
+	}
+	if c.Validate {
+		opts.Validate = true
+	}
+	if c.ValidateRequests {
+		opts.ValidateRequests = true
+	}
+	if len(c.RawJSON) > 0 {
+		opts.RawJSON = c.RawJSON
+	}
+	if len(c.Timeouts) > 0 {
+		if opts.Timeouts == nil {
+			opts.Timeouts = make(map[string]string, len(c.Timeouts))
+		}
+		for id, timeout := range c.Timeouts {
+			opts.Timeouts[id] = timeout
+		}
+	}
+	if c.StreamArrays {
+		opts.StreamArrays = true
+	}
+}
+
+func resolvePath(dir, path string) string {
+	if path == "" || path == StdioPath || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,108 @@
+package apiClient
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// csvMediaType is the media type of CSV bodies, decoded one row per
+// record.
+const csvMediaType = "text/csv"
+
+// readCSVFunc and csvRowsFunc are the names of the generic functions
+// decoding the rows of a CSV body and of a streamed CSV response, emitted
+// alongside the client.
+const (
+	readCSVFunc = "ReadCSV"
+	csvRowsFunc = "CSVRows"
+)
+
+func isCSV(name string, _ *v3.MediaType) bool {
+	name, _, _ = strings.Cut(name, ";")
+	return strings.TrimSpace(name) == csvMediaType
+}
+
+// csvResponse makes r the CSV response named after suffix, decoded into
+// a slice of its rows, if content documents text/csv with a schema of its
+// rows and r is not an error. It reports whether it did; other CSV bodies
+// are kept as text.
+func (g *generator) csvResponse(data *operationData, r *responseData, suffix string, content *orderedmap.Map[string, *v3.MediaType]) bool {
+	mt, ok := mediaTypeContent(content, csvMediaType)
+	if !ok || isErrorStatus(r.Code) {
+		return false
+	}
+	row := csvRowSchema(mt)
+	if row == nil || !g.csvFuncs(data) {
+		return false
+	}
+	r.Row = g.itemType(data, row, "Row", "a row of the "+r.Code+" response")
+	r.Field, r.Type, r.CSV = "CSV"+suffix, "[]"+r.Row, true
+	g.negotiate(r, content, mediaTypes(content, isCSV))
+	return true
+}
+
+// csvFuncs sets the names of the CSV functions on data, which are then
+// emitted alongside the client, or reports false if a schema already takes
+// one of them.
+func (g *generator) csvFuncs(data *operationData) bool {
+	if !g.usesCSV && (g.models[readCSVFunc] || g.models[csvRowsFunc]) {
+		g.log().Warn("function name for CSV rows is taken, leaving CSV undecoded", "operation", data.Name, "function", readCSVFunc)
+		return false
+	}
+	g.usesCSV = true
+	g.models[readCSVFunc], g.models[csvRowsFunc] = true, true
+	data.CSVFunc, data.RowsFunc = g.qualifier+readCSVFunc, g.qualifier+csvRowsFunc
+	return true
+}
+
+// csvRowSchema returns the schema of a row of mt, its itemSchema or the
+// items of an array schema, if it is an object with properties, whose
+// JSON names match the columns of the header record.
+func csvRowSchema(mt *v3.MediaType) *base.SchemaProxy {
+	row := mt.ItemSchema
+	if row == nil && mt.Schema != nil {
+		if schema := mt.Schema.Schema(); schema != nil && schemaType(schema) == "array" && schema.Items != nil && schema.Items.IsA() {
+			row = schema.Items.A
+		}
+	}
+	if row == nil {
+		return nil
+	}
+	if schema := row.Schema(); schema == nil || schemaType(schema) != "object" || orderedmap.Len(schema.Properties) == 0 {
+		return nil
+	}
+	return row
+}
+
+// buildRows adds to data the method yielding the rows of a text/csv
+// response of op that is not an error, such as ExportPetsRows, decoding
+// one record at a time as it is received rather than the whole body. The
+// method asks for CSV only, also when the response may be JSON.
+func (g *generator) buildRows(data *operationData, op *v3.Operation) {
+	mt := resultMediaType(op, csvMediaType)
+	if mt == nil {
+		return
+	}
+	for _, r := range data.Responses {
+		if r.CSV && !r.Error {
+			data.RowType = r.Row
+			break
+		}
+	}
+	if data.RowType == "" {
+		row := csvRowSchema(mt)
+		if row == nil || !g.csvFuncs(data) {
+			return
+		}
+		data.RowType = g.itemType(data, row, "Row", "a row of the response")
+	}
+	data.Rows = data.Name + "Rows"
+	if free := freeName(data.Rows, g.methods); free != data.Rows {
+		g.renamed("operation", data.Method+" "+data.Path+" rows", data.Rows, free)
+		data.Rows = free
+	}
+	g.methods[data.Rows] = true
+}
@@ -0,0 +1,56 @@
+package apiClient
+
+import (
+	"strings"
+	"testing"
+)
+
+const csvSpec = `
+openapi: 3.0.3
+info: {title: csv, version: "1"}
+paths:
+  /report:
+    get:
+      operationId: exportReport
+      responses:
+        "200":
+          description: ok
+          content:
+            text/csv:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    name: {type: string}
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {type: object, properties: {name: {type: string}}}
+            text/csv:
+              schema:
+                type: array
+                items: {type: object, properties: {name: {type: string}}}
+`
+
+// TestRowsAccept checks that the Rows methods ask for text/csv once.
+func TestRowsAccept(t *testing.T) {
+	src := string(generateFile(t, csvSpec))
+	for _, method := range []string{"ExportReportRows", "ListPetsRows"} {
+		_, body, ok := strings.Cut(src, "func (c *Client) "+method+"(")
+		if !ok {
+			t.Fatalf("no %s method", method)
+		}
+		body, _, _ = strings.Cut(body, "\n}\n")
+		if n := strings.Count(body, `req.Header.Set("Accept", "text/csv")`); n != 1 {
+			t.Errorf("%s sets Accept to text/csv %d times, want 1", method, n)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package apiClient
+
+import "strings"
+
+// breakCycles makes pointers of the struct fields through which a model
+// would contain itself by value, such as the required parent of a Category
+// that is a Category too, which Go rejects as an invalid recursive type.
+// Fields are visited in order, so the first field closing a cycle takes the
+// pointer and the others are left as they are. Slices and maps already
+// break cycles.
+func (g *generator) breakCycles(models []modelData) {
+	byName := make(map[string]*modelData, len(models))
+	for i := range models {
+		byName[models[i].Name] = &models[i]
+	}
+	for i := range models {
+		m := &models[i]
+		for j := range m.Fields {
+			f := &m.Fields[j]
+			name, pointer := valueType(f.Type)
+			if byName[name] == nil || !reaches(byName, name, m.Name, map[string]bool{}) {
+				continue
+			}
+			g.log().Debug("pointer breaks cycle", "type", m.Name, "field", f.Name)
+			f.Type = pointer
+			if c := m.Constructor; c != nil {
+				for k := range c.Params {
+					if c.Params[k].Field == f.Name {
+						c.Params[k].Type = pointer
+					}
+				}
+			}
+		}
+	}
+}
+
+// reaches reports whether the model from contains the model target by
+// value, directly or not.
+func reaches(byName map[string]*modelData, from, target string, seen map[string]bool) bool {
+	if from == target {
+		return true
+	}
+	m := byName[from]
+	if m == nil || seen[from] {
+		return false
+	}
+	seen[from] = true
+	types := []string{m.Type}
+	for _, f := range m.Fields {
+		types = append(types, f.Type)
+	}
+	for _, typ := range types {
+		if name, _ := valueType(typ); name != "" && reaches(byName, name, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueType returns the named type held by value in the Go type
+// expression typ, either typ itself or the argument of an Optional or Null
+// type, along with typ holding a pointer to it instead. It returns an empty
+// name for pointers, slices, maps and other types.
+func valueType(typ string) (name, pointer string) {
+	for _, wrapper := range []string{"Optional[", "Null["} {
+		if strings.HasPrefix(typ, wrapper) && strings.HasSuffix(typ, "]") {
+			inner := strings.TrimSuffix(strings.TrimPrefix(typ, wrapper), "]")
+			if isIdentifier(inner) {
+				return inner, wrapper + "*" + inner + "]"
+			}
+			return "", ""
+		}
+	}
+	if isIdentifier(typ) {
+		return typ, "*" + typ
+	}
+	return "", ""
+}
@@ -0,0 +1,111 @@
+package apiClient
+
+import (
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// constructorData describes the NewX function of a struct model, taking
+// its required properties and setting the defaults of the others.
+type constructorData struct {
+	Name   string
+	Params []paramData
+	// Defaults are the statements setting default values on the struct,
+	// which is named v.
+	Defaults []string
+}
+
+// paramData is a parameter of a constructor, stored in Field.
+type paramData struct {
+	Name  string
+	Type  string
+	Field string
+}
+
+// constructor returns the constructor of the struct m generated for the
+// given properties, or nil if it would have nothing to do or its name is
+// taken.
+func (g *generator) constructor(m *modelData, props *orderedmap.Map[string, *base.SchemaProxy], required []string) *constructorData {
+	if orderedmap.Len(props) == 0 {
+		return nil
+	}
+	c := &constructorData{Name: "New" + m.Name}
+	required = directionalRequired(props, required)
+	for _, f := range m.Fields {
+		if f.Embedded {
+			continue
+		}
+		prop, _ := props.Get(f.JSONName)
+		if prop == nil {
+			continue
+		}
+		if slices.Contains(required, f.JSONName) {
+			name := unexportedName(f.Name)
+			if token.IsKeyword(name) || name == "v" {
+				name += "Value"
+			}
+			c.Params = append(c.Params, paramData{Name: name, Type: f.Type, Field: f.Name})
+			continue
+		}
+		c.Defaults = append(c.Defaults, g.defaultStatements(f, prop.Schema())...)
+	}
+	if len(c.Params) == 0 && len(c.Defaults) == 0 {
+		return nil
+	}
+	if g.models[c.Name] {
+		g.log().Warn("constructor name is taken, skipping it", "type", m.Name, "name", c.Name)
+		return nil
+	}
+	return c
+}
+
+// defaultStatements returns the statements setting the field f of v to
+// the default value of schema. Only defaults of strings, numbers and
+// booleans held by basic types, or types defined as them, are supported.
+func (g *generator) defaultStatements(f fieldData, schema *base.Schema) []string {
+	if schema == nil || schema.Default == nil || schema.Default.Tag == "!!null" ||
+		g.mapsToNamedType(schema) || extension(schema, goTypeExtension) != "" || f.raw {
+		return nil
+	}
+	value := schema.Default.Value
+	lit := value
+	switch schemaType(schema) {
+	case "string":
+		lit = strconv.Quote(value)
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return nil
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	typ := f.Type
+	for _, wrapper := range []string{"Optional", "Null"} {
+		if inner, ok := strings.CutPrefix(typ, g.qualifier+wrapper+"["); ok {
+			constructor := map[string]string{"Optional": "Some", "Null": "NullValue"}[wrapper]
+			return []string{"v." + f.Name + " = " + g.qualifier + constructor + "[" + strings.TrimSuffix(inner, "]") + "](" + lit + ")"}
+		}
+	}
+	if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "interface{}" {
+		return nil
+	}
+	if elem, ok := strings.CutPrefix(typ, "*"); ok {
+		local := "default" + f.Name
+		return []string{local + " := " + elem + "(" + lit + ")", "v." + f.Name + " = &" + local}
+	}
+	return []string{"v." + f.Name + " = " + lit}
+}
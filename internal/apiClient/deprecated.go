@@ -0,0 +1,128 @@
+package apiClient
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// DeprecatedPolicy selects how operations, schemas and properties marked
+// "deprecated: true" are generated.
+type DeprecatedPolicy string
+
+const (
+	// DeprecatedMark generates deprecated items with a "Deprecated:"
+	// comment, which go vet and editors surface to callers.
+	DeprecatedMark DeprecatedPolicy = "mark"
+	// DeprecatedSkip leaves deprecated items out. Deprecated schemas are
+	// still generated, and marked, when a generated model refers to them.
+	DeprecatedSkip DeprecatedPolicy = "skip"
+)
+
+func (p DeprecatedPolicy) validate() error {
+	switch p {
+	case "", DeprecatedMark, DeprecatedSkip:
+		return nil
+	}
+	return fmt.Errorf("invalid deprecated policy %q: want %s or %s", p, DeprecatedMark, DeprecatedSkip)
+}
+
+func (g *generator) skipDeprecated() bool {
+	return g.opts.Deprecated == DeprecatedSkip
+}
+
+func isDeprecated(deprecated *bool) bool {
+	return deprecated != nil && *deprecated
+}
+
+// requiredSchemas returns the names of the component schemas to generate
+// when deprecated schemas are skipped: every schema that is not deprecated,
+// plus the deprecated ones they or the generated operations refer to,
+// directly or not.
+func (g *generator) requiredSchemas() map[string]bool {
+	schemas := g.doc.Components.Schemas
+	required := map[string]bool{}
+	var queue []string
+	for name, proxy := range schemas.FromOldest() {
+		if schema := proxy.Schema(); schema == nil || !isDeprecated(schema.Deprecated) {
+			required[name] = true
+			queue = append(queue, name)
+		}
+	}
+	require := func(ref string) {
+		if n := refName(ref); !required[n] {
+			required[n] = true
+			queue = append(queue, n)
+		}
+	}
+	if g.doc.Paths != nil {
+		for path, item := range g.doc.Paths.PathItems.FromOldest() {
+			for method, op := range pathOperations(item) {
+				if !g.opts.Filter.includes(method, path, op) || isDeprecated(op.Deprecated) {
+					continue
+				}
+				for _, proxy := range bodySchemas(op) {
+					visitRefs(proxy, require)
+				}
+				for _, p := range operationParameters(item, op) {
+					if p.Schema != nil {
+						visitRefs(p.Schema, require)
+					}
+				}
+			}
+		}
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		proxy, ok := schemas.Get(name)
+		if !ok {
+			continue
+		}
+		visitRefs(proxy, require)
+	}
+	return required
+}
+
+// visitRefs calls visit for every schema reference reachable from proxy
+// without following references, ignoring deprecated properties.
+func visitRefs(proxy *base.SchemaProxy, visit func(ref string)) {
+	if proxy == nil {
+		return
+	}
+	if proxy.IsReference() {
+		visit(proxy.GetReference())
+		return
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		return
+	}
+	for _, p := range schema.Properties.FromOldest() {
+		if !isDeprecatedProperty(p) {
+			visitRefs(p, visit)
+		}
+	}
+	for _, list := range [][]*base.SchemaProxy{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, p := range list {
+			visitRefs(p, visit)
+		}
+	}
+	if schema.Items != nil && schema.Items.IsA() {
+		visitRefs(schema.Items.A, visit)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+		visitRefs(schema.AdditionalProperties.A, visit)
+	}
+	visitRefs(schema.Not, visit)
+}
+
+// isDeprecatedProperty reports whether an inline property schema is marked
+// deprecated. Referenced schemas are deprecated as a whole instead.
+func isDeprecatedProperty(proxy *base.SchemaProxy) bool {
+	if proxy.IsReference() {
+		return false
+	}
+	schema := proxy.Schema()
+	return schema != nil && isDeprecated(schema.Deprecated)
+}
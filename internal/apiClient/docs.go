@@ -0,0 +1,133 @@
+package apiClient
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// maxInlineExample is the length up to which the example of a field is
+// given on one line.
+const maxInlineExample = 60
+
+// exampleNode returns the example of schema: its example, or the first of
+// its OpenAPI 3.1 examples.
+func exampleNode(schema *base.Schema) *yaml.Node {
+	switch {
+	case schema == nil:
+		return nil
+	case schema.Example != nil:
+		return schema.Example
+	case len(schema.Examples) > 0:
+		return schema.Examples[0]
+	}
+	return nil
+}
+
+// exampleJSON returns the example of schema as JSON, indented if indent is
+// set, or an empty string if it has none.
+func exampleJSON(schema *base.Schema, indent bool) string {
+	node := exampleNode(schema)
+	if node == nil {
+		return ""
+	}
+	value, err := exampleValue(node)
+	if err != nil {
+		return ""
+	}
+	var data []byte
+	if indent {
+		data, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		data, err = json.Marshal(value)
+	}
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// exampleValue decodes the YAML node of an example into the value its JSON
+// encodes. Timestamps keep the text they are written as, where decoding
+// would give a time.Time with a time of day and zone.
+func exampleValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.AliasNode:
+		if len(node.Content) > 0 {
+			return exampleValue(node.Content[0])
+		}
+		if node.Alias != nil {
+			return exampleValue(node.Alias)
+		}
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v, err := exampleValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[node.Content[i].Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]interface{}, 0, len(node.Content))
+		for _, n := range node.Content {
+			v, err := exampleValue(n)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		return s, nil
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!timestamp" {
+			return node.Value, nil
+		}
+	}
+	var value interface{}
+	err := node.Decode(&value)
+	return value, err
+}
+
+// typeExample returns the example of a schema generated as a type, as the
+// indented code block of a doc comment.
+func typeExample(schema *base.Schema) string {
+	example := exampleJSON(schema, true)
+	if example == "" {
+		return ""
+	}
+	return "\t" + strings.ReplaceAll(example, "\n", "\n\t")
+}
+
+// fieldDoc returns the doc comment text of the field of a property: its
+// description and, if withExample is set, its example. Those of a
+// referenced schema are left to its type.
+func fieldDoc(prop *base.SchemaProxy, withExample bool) string {
+	schema := prop.Schema()
+	if schema == nil || prop.IsReference() {
+		return ""
+	}
+	doc := strings.TrimSpace(schema.Description)
+	example := ""
+	if withExample {
+		example = exampleJSON(schema, false)
+	}
+	switch {
+	case example == "":
+	case len(example) > maxInlineExample:
+		doc = joinParagraphs(doc, "Example:\n\n"+typeExample(schema))
+	default:
+		doc = joinParagraphs(doc, "Example: "+example)
+	}
+	return doc
+}
+
+// joinParagraphs joins the non-empty ones of a and b with a blank line.
+func joinParagraphs(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + "\n\n" + b
+}
@@ -0,0 +1,140 @@
+package apiClient
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const docsSpec = `
+openapi: 3.0.3
+info: {title: docs, version: "1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {type: array, items: {type: string}}
+    post:
+      operationId: createPet
+      summary: Create a pet
+      description: Creates a pet in the store.
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, properties: {name: {type: string}}}
+      responses:
+        "204": {description: ok}
+  /pets/{id}:
+    get:
+      operationId: getPet
+      deprecated: true
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "204": {description: ok}
+    delete:
+      operationId: deletePet
+      description: Deletes a pet.
+      deprecated: true
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "204": {description: ok}
+`
+
+// TestDocStart checks that a summary or description starts the doc comment
+// of a name as a sentence of its own.
+func TestDocStart(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"ListPets", "List pets", "ListPets: List pets."},
+		{"ListPets", "list pets", "ListPets: List pets."},
+		{"ListPets", "Lists pets", "ListPets lists pets."},
+		{"SearchPets", "Searches the pets.", "SearchPets searches the pets."},
+		{"ApplyDiscount", "Applies a discount", "ApplyDiscount applies a discount."},
+		{"Pet", "A pet", "Pet is a pet."},
+		{"Pet", "The pet of an owner.\n\nIt has a name", "Pet is the pet of an owner.\n\nIt has a name"},
+		{"Owner", "Pet owner", "Owner: Pet owner."},
+		{"Pets", "Pets of an owner?", "Pets: Pets of an owner?"},
+		{"Order", "is an order.", "Order is an order."},
+	}
+	for _, tt := range tests {
+		if got := docStart(tt.name, tt.text); got != tt.want {
+			t.Errorf("docStart(%q, %q) = %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestParamsDoc checks that the doc comment of a Params struct says that
+// optional parameters are left out only when it has some.
+func TestParamsDoc(t *testing.T) {
+	src := string(generateFile(t, `
+openapi: 3.0.3
+info: {title: params doc, version: "1"}
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        "204": {description: ok}
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "204": {description: ok}
+`))
+	for _, want := range []string{
+		"// GetPetParams holds the parameters of GetPet.\ntype",
+		"// ListPetsParams holds the parameters of ListPets. Optional ones are left out\n// when nil.\ntype",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("no %q in:\n%s", want, src)
+		}
+	}
+}
+
+// TestOperationDocs checks that the doc comment of an operation is attached
+// to its method, rather than separated from it by a blank line.
+func TestOperationDocs(t *testing.T) {
+	src := generateFile(t, docsSpec)
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := map[string]string{}
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+			docs[fn.Name.Name] = fn.Doc.Text()
+		}
+	}
+	for name, want := range map[string]string{
+		"ListPets":  "ListPets: List pets.\n",
+		"CreatePet": "CreatePet: Create a pet.\n\nCreates a pet in the store.\n",
+		"GetPet":    "Deprecated: GetPet is deprecated by the API.\n",
+		"DeletePet": "DeletePet deletes a pet.\n\nDeprecated: DeletePet is deprecated by the API.\n",
+	} {
+		if got := docs[name]; got != want {
+			t.Errorf("doc of %s = %q, want %q", name, got, want)
+		}
+	}
+	// No comment of the file is detached from the function following it.
+	for _, comment := range file.Comments {
+		if rest := src[fset.Position(comment.End()).Offset:]; strings.HasPrefix(string(rest), "\n\nfunc ") {
+			t.Errorf("comment %q is detached from the function after it", comment.Text())
+		}
+	}
+}
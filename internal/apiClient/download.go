@@ -0,0 +1,74 @@
+package apiClient
+
+// buildDownload adds to data the method writing its binary responses to an
+// io.Writer as they are received, rather than reading them into memory,
+// if it has any that are not errors.
+func (g *generator) buildDownload(data *operationData) {
+	for i, r := range data.Responses {
+		data.Responses[i].Download = r.Binary && !r.Error
+		if data.Responses[i].Download {
+			data.Download = data.Name + "To"
+		}
+	}
+	if data.Download == "" {
+		return
+	}
+	if free := freeName(data.Download, g.methods); free != data.Download {
+		g.renamed("operation", data.Method+" "+data.Path+" to writer", data.Download, free)
+		data.Download = free
+	}
+	g.methods[data.Download] = true
+	g.buildToFile(data)
+	g.buildRange(data)
+}
+
+// filenameFunc and saveFileFunc are the names of the functions returning
+// the file name a response suggests and saving a download to a file,
+// emitted alongside the client.
+const (
+	filenameFunc = "SuggestedFilename"
+	saveFileFunc = "SaveFile"
+)
+
+// buildToFile adds to data, which has a Download method, the method saving
+// the binary bodies to a file, and the Filename method of its result.
+func (g *generator) buildToFile(data *operationData) {
+	if !g.usesFiles && (g.models[filenameFunc] || g.models[saveFileFunc]) {
+		g.log().Warn("function name for saving downloads is taken: no method saving to a file", "operation", data.Name, "function", saveFileFunc)
+		return
+	}
+	g.usesFiles = true
+	g.models[filenameFunc], g.models[saveFileFunc] = true, true
+	data.FilenameFunc, data.SaveFunc = g.qualifier+filenameFunc, g.qualifier+saveFileFunc
+
+	data.ToFile = data.Name + "ToFile"
+	if free := freeName(data.ToFile, g.methods); free != data.ToFile {
+		g.renamed("operation", data.Method+" "+data.Path+" to file", data.ToFile, free)
+		data.ToFile = free
+	}
+	g.methods[data.ToFile] = true
+}
+
+// Streamed returns o as the data of its Download method.
+func (o operationData) Streamed() operationData {
+	o.Streaming = true
+	return o
+}
+
+// Saved returns o as the data of its ToFile method.
+func (o operationData) Saved() operationData {
+	o.Streaming, o.Saving = true, true
+	return o
+}
+
+// DownloadCases returns the responses of o up to the last one marked
+// Download, whose switch cases select the bodies to write to the io.Writer.
+func (o operationData) DownloadCases() []responseData {
+	last := -1
+	for i, r := range o.Responses {
+		if r.Download {
+			last = i
+		}
+	}
+	return o.Responses[:last+1]
+}
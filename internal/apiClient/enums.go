@@ -0,0 +1,92 @@
+package apiClient
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// enumValue is one constant of an enum type.
+type enumValue struct {
+	Name string
+	// Value is the Go literal of the constant.
+	Value string
+}
+
+// enumModel returns the model of the enum type name for schema, or false
+// if schema has no enum of strings, integers or numbers. Null values of
+// nullable enums are left out.
+func (g *generator) enumModel(name string, schema *base.Schema) (modelData, bool) {
+	if len(schema.Enum) == 0 {
+		return modelData{}, false
+	}
+	var underlying string
+	switch schemaType(schema) {
+	case "string":
+		underlying = "string"
+	case "integer":
+		underlying = "int"
+	case "number":
+		underlying = "float64"
+	default:
+		return modelData{}, false
+	}
+
+	m := modelData{
+		Name:        name,
+		Description: schema.Description,
+		Example:     typeExample(schema),
+		Deprecated:  isDeprecated(schema.Deprecated),
+		Type:        underlying,
+		Lenient:     g.opts.LenientEnums,
+	}
+	seen := map[string]bool{}
+	for i, node := range schema.Enum {
+		if node == nil || node.Tag == "!!null" {
+			continue
+		}
+		lit := node.Value
+		switch underlying {
+		case "string":
+			lit = strconv.Quote(node.Value)
+		case "int":
+			if _, err := strconv.ParseInt(node.Value, 10, 64); err != nil {
+				return modelData{}, false
+			}
+		case "float64":
+			if _, err := strconv.ParseFloat(node.Value, 64); err != nil {
+				return modelData{}, false
+			}
+		}
+		constName := name + g.enumConstSuffix(node.Value)
+		if constName == name || seen[constName] || g.models[constName] {
+			wanted := constName
+			constName = freeName(name+"Value"+strconv.Itoa(i), g.models)
+			g.renamed("enum", name+" "+node.Value, wanted, constName)
+		}
+		seen[constName] = true
+		m.Enum = append(m.Enum, enumValue{Name: constName, Value: lit})
+	}
+	if len(m.Enum) == 0 {
+		return modelData{}, false
+	}
+	for _, e := range m.Enum {
+		g.models[e.Name] = true
+	}
+	return m, true
+}
+
+// enumConstSuffix returns the part of a constant name derived from an enum
+// value, such as "Available" for "available" or "1" for 1.
+func (g *generator) enumConstSuffix(value string) string {
+	if value == "" {
+		return "Empty"
+	}
+	prefix := ""
+	if strings.HasPrefix(value, "-") {
+		prefix = "Minus"
+	}
+	// The type name precedes the suffix, so it may start with a digit.
+	return prefix + strings.TrimPrefix(g.goName("x_"+value), "X")
+}
@@ -0,0 +1,83 @@
+package apiClient
+
+import "strings"
+
+// responseError is the error type of the responses with a status of 400 or
+// above, emitted next to the client type.
+const responseError = "ResponseError"
+
+// messageProperties are the properties holding the message of an error
+// body, in order of preference.
+var messageProperties = []string{"message", "detail", "title", "error_description", "description", "msg"}
+
+// errorData describes the Error method of a struct model that is the body
+// of an error response, which returns Value, a string property of its
+// message, if Guard holds, and else the body encoded as JSON.
+type errorData struct {
+	Guard string
+	Value string
+}
+
+// buildErrors adds an Error method to the struct models that are the body
+// of a response with a status of 400 or above, so that errors.As finds
+// them in the ResponseError the methods return. Models with a field named
+// Error cannot have the method; their error holds them all the same.
+func (g *generator) buildErrors(models []modelData, ops []operationData) {
+	// The types of the operations of a per-tag package refer to the models
+	// of the core package by qualified names.
+	byName := map[string]*modelData{}
+	for i := range models {
+		byName[models[i].Name] = &models[i]
+	}
+	declaredIn := map[*modelData]string{}
+	for _, op := range ops {
+		qualifier := strings.TrimSuffix(op.ResponseError, responseError)
+		types := map[string]*modelData{}
+		for j := range op.Types {
+			types[op.Types[j].Name] = &op.Types[j]
+			declaredIn[&op.Types[j]] = qualifier
+		}
+		for _, r := range op.Responses {
+			name, ok := strings.CutPrefix(r.Type, "*")
+			if !r.Error || !r.Decoded() || !ok {
+				continue
+			}
+			m := types[name]
+			if m == nil {
+				m = byName[strings.TrimPrefix(name, qualifier)]
+			}
+			if m != nil && m.Alias {
+				m = byName[strings.TrimPrefix(m.Type, qualifier)]
+			}
+			if m == nil || !m.Struct || m.Error != nil {
+				continue
+			}
+			m.Error = errorMethod(*m, declaredIn[m])
+		}
+	}
+}
+
+// errorMethod returns the Error method of m, or nil if a field takes its
+// name. Types declared in another package are prefixed with qualifier.
+func errorMethod(m modelData, qualifier string) *errorData {
+	for _, f := range m.Fields {
+		name := strings.TrimPrefix(f.Type, "*")
+		if f.Embedded && name[strings.LastIndex(name, ".")+1:] == "Error" || !f.Embedded && f.Name == "Error" {
+			return nil
+		}
+	}
+	for _, property := range messageProperties {
+		for _, f := range m.Fields {
+			if f.Embedded || f.JSONName != property {
+				continue
+			}
+			if c, typ, ok := fieldValue(f, qualifier); ok && typ == "string" {
+				if c.Guard == "" {
+					c.Guard = c.Value + ` != ""`
+				}
+				return &errorData{Guard: c.Guard, Value: c.Value}
+			}
+		}
+	}
+	return &errorData{}
+}
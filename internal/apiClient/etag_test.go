@@ -0,0 +1,124 @@
+package apiClient
+
+import "testing"
+
+const etagSpec = `
+openapi: 3.0.3
+info: {title: etag, version: "1"}
+paths:
+  /items/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    get:
+      operationId: getItem
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Item'}
+    put:
+      operationId: putItem
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Item'}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Item'}
+        "412":
+          description: changed
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const etagMain = `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	version := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("server %s If-None-Match=%s If-Match=%s\n", r.Method, r.Header.Get("If-None-Match"), r.Header.Get("If-Match"))
+		etag := "\"v" + strconv.Itoa(version) + "\""
+		switch {
+		case r.Method == http.MethodGet && r.Header.Get("If-None-Match") == etag:
+			w.WriteHeader(http.StatusNotModified)
+			return
+		case r.Method == http.MethodPut && r.Header.Get("If-Match") != etag:
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		case r.Method == http.MethodPut:
+			version++
+			etag = "\"v" + strconv.Itoa(version) + "\""
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "{\"name\":\"a\"}")
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	params := client.GetItemParams{ID: "1"}
+	a := client.NewClient(srv.URL, client.WithETags())
+	b := client.NewClient(srv.URL, client.WithETags())
+	get := func(c *client.Client) {
+		resp, err := c.GetItem(ctx, params)
+		if err != nil {
+			fmt.Println("error", err)
+			return
+		}
+		fmt.Println("client", resp.StatusCode(), resp.NotModified(), resp.JSON200 != nil)
+	}
+	put := func(c *client.Client) {
+		resp, err := c.PutItem(ctx, client.PutItemParams{ID: "1"}, client.Item{Name: "b"})
+		var respErr *client.ResponseError
+		switch {
+		case errors.As(err, &respErr):
+			fmt.Println("client error", respErr.StatusCode)
+		case err != nil:
+			fmt.Println("error", err)
+		default:
+			fmt.Println("client", resp.StatusCode())
+		}
+	}
+	get(a)
+	get(a)
+	get(b)
+	put(a)
+	get(a)
+	put(b)
+}
+`
+
+// TestETags checks that the ETag of a response is sent back in If-None-Match
+// on GET requests, which a 304 answers, and in If-Match on PUT requests,
+// which fail with 412 once the resource changed.
+func TestETags(t *testing.T) {
+	got := runGenerated(t, etagSpec, etagMain)
+	want := "server GET If-None-Match= If-Match=\nclient 200 false true\n" +
+		"server GET If-None-Match=\"v1\" If-Match=\nclient 304 true false\n" +
+		"server GET If-None-Match= If-Match=\nclient 200 false true\n" +
+		"server PUT If-None-Match= If-Match=\"v1\"\nclient 200\n" +
+		"server GET If-None-Match=\"v2\" If-Match=\nclient 304 true false\n" +
+		"server PUT If-None-Match= If-Match=\"v1\"\nclient error 412\n"
+	if got != want {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package apiClient
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// eventStreamMediaType is the media type of server-sent events.
+const eventStreamMediaType = "text/event-stream"
+
+// eventType and eventsFunc are the names of the generic type of a
+// server-sent event and of the function reading them, emitted alongside
+// the client.
+const (
+	eventType  = "ServerSentEvent"
+	eventsFunc = "ServerSentEvents"
+)
+
+// buildEvents adds to data the method yielding the server-sent events of
+// op, such as WatchPetsEvents, if a response that is not an error is a
+// text/event-stream. The data of the events is decoded as JSON into the
+// type of its schema, unless it is a string.
+func (g *generator) buildEvents(data *operationData, op *v3.Operation) {
+	mt := resultMediaType(op, eventStreamMediaType)
+	if mt == nil {
+		return
+	}
+	if data.RequestType != "" {
+		g.log().Warn("event stream of an operation with a request body, which cannot be sent again on reconnecting: no events method", "operation", data.Name)
+		return
+	}
+	if !g.usesEvents && (g.models[eventType] || g.models[eventsFunc]) {
+		g.log().Warn("type name for server-sent events is taken: no events method", "operation", data.Name, "type", eventType)
+		return
+	}
+	g.usesEvents = true
+	g.models[eventType], g.models[eventsFunc] = true, true
+
+	data.Events = data.Name + "Events"
+	if free := freeName(data.Events, g.methods); free != data.Events {
+		g.renamed("operation", data.Method+" "+data.Path+" events", data.Events, free)
+		data.Events = free
+	}
+	g.methods[data.Events] = true
+	data.EventType, data.EventFunc = g.qualifier+eventType, g.qualifier+eventsFunc
+	data.EventData = "string"
+	if schema := mt.Schema; schema != nil && (schema.IsReference() || schema.Schema() == nil || schemaType(schema.Schema()) != "string") {
+		data.EventData = g.itemType(data, schema, "Event", "the data of the events")
+	}
+}
+
+// itemType returns the Go type of the items of a stream of data, such as
+// its events, described by schema, declaring a struct named after data and
+// suffix on data for an inline object, such as WatchPetsEvent. what
+// describes the items in its doc comment.
+func (g *generator) itemType(data *operationData, schema *base.SchemaProxy, suffix, what string) string {
+	name := g.typeName(data.Name + suffix)
+	g.at, g.typeAt = name, name
+	if object := inlineObject(schema); object != nil {
+		if free := freeName(name, g.models); free != name {
+			g.renamed("type", data.Name+" "+strings.ToLower(suffix), name, free)
+			name = free
+			g.at, g.typeAt = name, name
+		}
+		g.models[name] = true
+		props, required, _ := withoutProperties(object, isWriteOnly)
+		m := modelData{
+			Name:        name,
+			Description: "is " + what + " of " + data.Name + ".",
+			Struct:      true,
+			Fields:      g.structFields(name, name, props, required),
+		}
+		g.additionalProperties(name, &m, object, props)
+		data.Types = append(data.Types, m)
+		return name
+	}
+	typ := g.goType(schema)
+	g.describePending(typ, "is "+what+" of "+data.Name+".")
+	return typ
+}
+
+// resultMediaType returns the first media type among mediaTypes of the
+// responses of op that are not errors, the default one last, or nil.
+func resultMediaType(op *v3.Operation, mediaTypes ...string) *v3.MediaType {
+	if op.Responses == nil {
+		return nil
+	}
+	find := func(resp *v3.Response) *v3.MediaType {
+		for name, mt := range resp.Content.FromOldest() {
+			if name, _, _ = strings.Cut(name, ";"); slices.Contains(mediaTypes, strings.TrimSpace(name)) {
+				return mt
+			}
+		}
+		return nil
+	}
+	for code, resp := range op.Responses.Codes.FromOldest() {
+		if mt := find(resp); mt != nil && !isErrorStatus(code) {
+			return mt
+		}
+	}
+	if op.Responses.Default != nil {
+		return find(op.Responses.Default)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package apiClient
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Filter selects the operations to generate. Empty lists match everything;
+// excludes win over includes. Paths are matched against the path template
+// as written in the document (e.g. "/pets/{petId}") using path.Match
+// patterns, where a trailing "/**" also matches everything below the
+// prefix. Methods are matched case-insensitively.
+type Filter struct {
+	IncludeTags       []string `json:"includeTags" yaml:"includeTags"`
+	ExcludeTags       []string `json:"excludeTags" yaml:"excludeTags"`
+	IncludePaths      []string `json:"includePaths" yaml:"includePaths"`
+	ExcludePaths      []string `json:"excludePaths" yaml:"excludePaths"`
+	IncludeMethods    []string `json:"includeMethods" yaml:"includeMethods"`
+	ExcludeMethods    []string `json:"excludeMethods" yaml:"excludeMethods"`
+	IncludeOperations []string `json:"includeOperations" yaml:"includeOperations"`
+	ExcludeOperations []string `json:"excludeOperations" yaml:"excludeOperations"`
+}
+
+func (f Filter) merge(o Filter) Filter {
+	return Filter{
+		IncludeTags:       append(f.IncludeTags, o.IncludeTags...),
+		ExcludeTags:       append(f.ExcludeTags, o.ExcludeTags...),
+		IncludePaths:      append(f.IncludePaths, o.IncludePaths...),
+		ExcludePaths:      append(f.ExcludePaths, o.ExcludePaths...),
+		IncludeMethods:    append(f.IncludeMethods, o.IncludeMethods...),
+		ExcludeMethods:    append(f.ExcludeMethods, o.ExcludeMethods...),
+		IncludeOperations: append(f.IncludeOperations, o.IncludeOperations...),
+		ExcludeOperations: append(f.ExcludeOperations, o.ExcludeOperations...),
+	}
+}
+
+func (f Filter) validate() error {
+	for _, pattern := range slices.Concat(f.IncludePaths, f.ExcludePaths) {
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// includes reports whether the operation op on method and path passes the
+// filter.
+func (f Filter) includes(method, path string, op *v3.Operation) bool {
+	if slices.Contains(f.ExcludeOperations, op.OperationId) {
+		return false
+	}
+	for _, tag := range op.Tags {
+		if slices.Contains(f.ExcludeTags, tag) {
+			return false
+		}
+	}
+	if matchesPath(f.ExcludePaths, path) || containsFold(f.ExcludeMethods, method) {
+		return false
+	}
+
+	if len(f.IncludeOperations) > 0 && !slices.Contains(f.IncludeOperations, op.OperationId) {
+		return false
+	}
+	if len(f.IncludeTags) > 0 && !slices.ContainsFunc(op.Tags, func(tag string) bool {
+		return slices.Contains(f.IncludeTags, tag)
+	}) {
+		return false
+	}
+	if len(f.IncludePaths) > 0 && !matchesPath(f.IncludePaths, path) {
+		return false
+	}
+	if len(f.IncludeMethods) > 0 && !containsFold(f.IncludeMethods, method) {
+		return false
+	}
+	return true
+}
+
+// matchesPath reports whether p matches any of patterns.
+func matchesPath(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+			// Match the prefix against as many leading segments of p as
+			// it has.
+			n := strings.Count(prefix, "/")
+			if segs := strings.Split(p, "/"); len(segs) > n {
+				if ok, _ := path.Match(prefix, strings.Join(segs[:n+1], "/")); ok {
+					return true
+				}
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	return slices.ContainsFunc(list, func(v string) bool { return strings.EqualFold(v, s) })
+}
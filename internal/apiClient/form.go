@@ -0,0 +1,70 @@
+package apiClient
+
+import (
+	"strings"
+)
+
+const formMediaType = "application/x-www-form-urlencoded"
+
+// buildForm sets how the struct request body of data is encoded as an
+// application/x-www-form-urlencoded form: by the method itself, or, if
+// variant is set because the body may be JSON as well, by a method such as
+// CreatePetWithFormBody. Fields of embedded structs are encoded as those of
+// the body. A body that is not a struct is sent as JSON.
+func (g *generator) buildForm(data *operationData, variant bool) {
+	fields, qualifier, ok := g.requestFields(data)
+	if !ok {
+		if variant {
+			g.log().Warn("form request body is not an object, not generated", "operation", data.Name)
+		} else {
+			g.degraded("", "form request body is not an object, sent as JSON")
+		}
+		return
+	}
+	var parts []partData
+	var add func(fields []fieldData, qualifier string)
+	add = func(fields []fieldData, qualifier string) {
+		for _, f := range fields {
+			if !f.Embedded {
+				parts = append(parts, g.part(f, qualifier))
+				continue
+			}
+			// The fields of an embedded struct are promoted.
+			embedded, embeddedQualifier, ok := g.fieldsOf(strings.TrimPrefix(strings.TrimPrefix(f.Type, "*"), qualifier))
+			if !ok {
+				g.degraded("", "embedded "+f.Type+" is left out of the form body")
+				continue
+			}
+			add(embedded, embeddedQualifier)
+		}
+	}
+	add(fields, qualifier)
+	data.FormParts = parts
+	if !variant {
+		data.Form = true
+		return
+	}
+	data.FormBody = data.Name + "WithFormBody"
+	if free := freeName(data.FormBody, g.methods); free != data.FormBody {
+		g.renamed("operation", data.Method+" "+data.Path+" with form body", data.FormBody, free)
+		data.FormBody = free
+	}
+	g.methods[data.FormBody] = true
+}
+
+// requestFields returns the fields of the request type of data, declared
+// on it or a model it aliases or names, the qualifier of their types, and
+// whether it is a struct.
+func (g *generator) requestFields(data *operationData) ([]fieldData, string, bool) {
+	typ := data.RequestType
+	for _, m := range data.Types {
+		if m.Name != typ {
+			continue
+		}
+		if !m.Alias {
+			return m.Fields, g.qualifier, m.Struct
+		}
+		typ = m.Type
+	}
+	return g.fieldsOf(strings.TrimPrefix(typ, g.qualifier))
+}
@@ -0,0 +1,175 @@
+package apiClient
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// stdlibImports maps the package names generated code may reference to
+// their import paths.
+var stdlibImports = map[string]string{
+	"atomic":    "sync/atomic",
+	"base64":    "encoding/base64",
+	"big":       "math/big",
+	"bufio":     "bufio",
+	"bytes":     "bytes",
+	"context":   "context",
+	"csv":       "encoding/csv",
+	"encoding":  "encoding",
+	"errors":    "errors",
+	"filepath":  "path/filepath",
+	"flate":     "compress/flate",
+	"fmt":       "fmt",
+	"gzip":      "compress/gzip",
+	"io":        "io",
+	"iter":      "iter",
+	"json":      "encoding/json",
+	"list":      "container/list",
+	"log":       "log",
+	"maps":      "maps",
+	"math":      "math",
+	"mime":      "mime",
+	"multipart": "mime/multipart",
+	"http":      "net/http",
+	"net":       "net",
+	"os":        "os",
+	"path":      "path",
+	"rand":      "crypto/rand",
+	"reflect":   "reflect",
+	"regexp":    "regexp",
+	"slices":    "slices",
+	"sort":      "sort",
+	"sql":       "database/sql",
+	"strconv":   "strconv",
+	"strings":   "strings",
+	"sync":      "sync",
+	"textproto": "net/textproto",
+	"time":      "time",
+	"url":       "net/url",
+	"utf8":      "unicode/utf8",
+	"xml":       "encoding/xml",
+	"zlib":      "compress/zlib",
+}
+
+// formatSource rewrites the import block of src to exactly the packages it
+// references and formats the result with go/format. Packages are resolved
+// from imports already present in src, then from extraImports (full import
+// paths), then from the standard library table.
+func formatSource(src []byte, extraImports []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated code: %w", err)
+	}
+
+	known := make(map[string]string, len(stdlibImports)+len(extraImports))
+	for name, p := range stdlibImports {
+		known[name] = p
+	}
+	for _, p := range extraImports {
+		known[path.Base(p)] = p
+	}
+	aliases := map[string]string{}
+	for _, spec := range file.Imports {
+		p, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(p)
+		if spec.Name != nil {
+			name = spec.Name.Name
+			aliases[p] = name
+		}
+		known[name] = p
+	}
+
+	unresolved := map[string]bool{}
+	for _, id := range file.Unresolved {
+		unresolved[id.Name] = true
+	}
+	used := map[string]string{}
+	var missing []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		id, ok := sel.X.(*ast.Ident)
+		if !ok || !unresolved[id.Name] {
+			return true
+		}
+		if p, ok := known[id.Name]; ok {
+			used[id.Name] = p
+		} else if !slices.Contains(missing, id.Name) {
+			missing = append(missing, id.Name)
+		}
+		return true
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("generated code references unknown packages: %s", strings.Join(missing, ", "))
+	}
+
+	// Cut the existing import declarations and splice a fresh block in
+	// after the package clause.
+	var body bytes.Buffer
+	offset := 0
+	pkgEnd := fset.Position(file.Name.End()).Offset
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		body.Write(src[offset:fset.Position(gen.Pos()).Offset])
+		offset = fset.Position(gen.End()).Offset
+	}
+	body.Write(src[offset:])
+	rest := body.Bytes()[pkgEnd:]
+
+	var out bytes.Buffer
+	out.Write(src[:pkgEnd])
+	if len(used) > 0 {
+		out.WriteString("\n\nimport (\n")
+		paths := make([]string, 0, len(used))
+		for _, p := range used {
+			paths = append(paths, p)
+		}
+		// Standard library first, then everything else, as goimports does.
+		slices.SortFunc(paths, func(a, b string) int {
+			if sa, sb := isStdlib(a), isStdlib(b); sa != sb {
+				if sa {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a, b)
+		})
+		for i, p := range paths {
+			if i > 0 && isStdlib(paths[i-1]) && !isStdlib(p) {
+				out.WriteString("\n")
+			}
+			if alias, ok := aliases[p]; ok {
+				fmt.Fprintf(&out, "\t%s %q\n", alias, p)
+			} else {
+				fmt.Fprintf(&out, "\t%q\n", p)
+			}
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(rest)
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+func isStdlib(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,102 @@
+package apiClient
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// dateType is the name of the type generated for the "date" format, and
+// decimalType that of the exact decimal type that the "decimal" format can
+// be mapped to. Both name the generated type in type mappings.
+const (
+	dateType    = "Date"
+	decimalType = "Decimal"
+)
+
+// formatTypes maps well-known "type/format" keys to the Go type holding
+// them, used unless Options.TypeMappings has the same key. dateType stands
+// for the generated type of that name.
+var formatTypes = map[string]string{
+	"string/date-time": "time.Time",
+	"string/date":      dateType,
+	"string/uuid":      "github.com/google/uuid.UUID",
+	"string/byte":      "[]byte",
+	"integer/int32":    "int32",
+	"integer/int64":    "int64",
+	"number/float":     "float32",
+	"number/double":    "float64",
+	"number/decimal":   "float64",
+}
+
+// dateType returns the generated Date type, which is then emitted
+// alongside the client, or string if a schema already takes its name.
+func (g *generator) dateType() string {
+	if !g.usesDate && g.models[dateType] {
+		g.degraded("string", "type name "+dateType+" for the date format is taken")
+		return "string"
+	}
+	g.usesDate = true
+	g.models[dateType] = true
+	return g.qualifier + dateType
+}
+
+// decimalType returns the generated Decimal type, which is then emitted
+// alongside the client, or float64 if a schema already takes its name.
+func (g *generator) decimalType() string {
+	if !g.usesDecimal && g.models[decimalType] {
+		g.degraded("float64", "type name "+decimalType+" for the decimal format is taken")
+		return "float64"
+	}
+	g.usesDecimal = true
+	g.models[decimalType] = true
+	return g.qualifier + decimalType
+}
+
+// goTypeExtension replaces the type generated for a schema by an existing
+// Go type, either qualified by its import path, such as
+// "example.com/money.Amount", or qualified by its package name, such as
+// "money.Amount", with goTypeImportExtension giving the import path.
+const (
+	goTypeExtension       = "x-go-type"
+	goTypeImportExtension = "x-go-type-import"
+)
+
+// extensionType returns the type of the x-go-type extension of schema, if
+// any, and records the import it needs.
+func (g *generator) extensionType(schema *base.Schema) (string, bool) {
+	target := extension(schema, goTypeExtension)
+	if target == "" {
+		return "", false
+	}
+	if importPath := extension(schema, goTypeImportExtension); importPath != "" {
+		g.imports[importPath] = struct{}{}
+		return target, true
+	}
+	expr, importPath, err := parseGoType(target)
+	if err != nil {
+		g.log().Warn("invalid "+goTypeExtension+", ignored", "schema", g.at, "value", target)
+		return "", false
+	}
+	if importPath != "" {
+		g.imports[importPath] = struct{}{}
+	}
+	return expr, true
+}
+
+// extension returns the scalar value of the extension name of schema, or an
+// empty string.
+func extension(schema *base.Schema, name string) string {
+	if node, ok := extensionNode(schema, name); ok {
+		return node.Value
+	}
+	return ""
+}
+
+// extensionNode returns the value of the extension name of schema.
+func extensionNode(schema *base.Schema, name string) (*yaml.Node, bool) {
+	if schema == nil || schema.Extensions == nil {
+		return nil, false
+	}
+	node, ok := schema.Extensions.Get(name)
+	return node, ok && node != nil
+}
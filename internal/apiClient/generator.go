@@ -0,0 +1,600 @@
+// Package apiClient generates Go HTTP client code from an OpenAPI 3 document.
+package apiClient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// Options controls a single generation run.
+type Options struct {
+	// SpecPath is the path of the OpenAPI document to read.
+	SpecPath string
+	// MergeSpecPaths lists further documents whose paths and components
+	// are merged into the one at SpecPath, see MergeSpecs.
+	MergeSpecPaths []string
+	// OutPath is the path of the Go file to write, or of the output
+	// directory when Layout is LayoutSplit or LayoutPackages, or when
+	// Module is set.
+	OutPath string
+	// Module, when set, makes OutPath the root of a standalone module with
+	// this path: a go.mod, the client in a package directory named after
+	// PackageName, and an example program.
+	Module string
+	// Layout selects how the generated code is split into files.
+	Layout Layout
+	// ImportPath is the import path of the output directory, used by the
+	// packages layout to import the core package. When empty it is derived
+	// from the enclosing go.mod.
+	ImportPath string
+	// PackageName is the package clause of the generated file.
+	PackageName string
+	// ClientName is the name of the generated client type.
+	ClientName string
+	// TypeMappings overrides the Go type used for a schema type, keyed by
+	// "type" or "type/format" (e.g. "string/date-time"). Values are either
+	// builtin types or a qualified "import/path.Type" such as
+	// "github.com/google/uuid.UUID".
+	TypeMappings map[string]string
+	// Filter selects the operations to generate.
+	Filter Filter
+	// Deprecated selects how deprecated operations, schemas and properties
+	// are generated; the default is DeprecatedMark.
+	Deprecated DeprecatedPolicy
+	// Naming controls how generated identifiers are derived.
+	Naming Naming
+	// TemplatesDir is a directory of *.tmpl files whose definitions replace
+	// the embedded default templates of the same name.
+	TemplatesDir string
+	// HeaderFile is a file whose contents are put at the top of every
+	// generated Go file, typically a license. See loadHeader.
+	HeaderFile string
+	// Logger receives generation decisions and warnings, see
+	// generator.log. A nil Logger discards them.
+	Logger *slog.Logger
+	// PostHooks are commands run after the generated files have been
+	// written, see RunPostHooks.
+	PostHooks []string
+	// Report, when not nil, is filled with the outcome of every operation
+	// and degraded schema.
+	Report *Report
+	// LenientEnums makes generated enum types accept values outside of
+	// their enum when unmarshaled, instead of failing.
+	LenientEnums bool
+	// Optional selects how optional and nullable struct fields are
+	// represented; the default is OptionalValue.
+	Optional OptionalStrategy
+	// StructTags names the struct tags generated besides json: "validate"
+	// derives validator rules from the schema constraints, any other name
+	// such as "yaml" or "db" repeats the JSON name.
+	StructTags []string
+	// Validate adds a Validate method to every struct type, which checks
+	// the constraints of its schema such as minLength, pattern, maximum or
+	// minItems.
+	Validate bool
+	// ValidateRequests implies Validate, and makes every operation validate
+	// its request body before sending it.
+	ValidateRequests bool
+	// RawJSON names the schemas, such as Pet, and properties, such as
+	// Pet.metadata, generated as json.RawMessage to be decoded by the
+	// caller, like those with the x-go-raw extension.
+	RawJSON []string
+	// Timeouts maps operationIds to the timeout of their calls, as Go
+	// durations such as "2m" or numbers of seconds, taking precedence over
+	// the x-timeout extension of the operations. WithTimeout overrides it
+	// for a call.
+	Timeouts map[string]string
+	// StreamArrays gives every operation with a JSON array response a
+	// method such as ListPetsItems, yielding its items one at a time as
+	// they are decoded, for arrays too large to hold in memory.
+	StreamArrays bool
+	// NoCache disables the build cache, which skips rendering files whose
+	// inputs have not changed since they were last written.
+	NoCache bool
+}
+
+// DefaultOptions returns the options used when a field is left empty.
+func DefaultOptions() Options {
+	return Options{
+		OutPath:     "client_gen.go",
+		PackageName: "client",
+		ClientName:  "Client",
+	}
+}
+
+func (o *Options) applyDefaults() {
+	def := DefaultOptions()
+	if o.OutPath == "" {
+		o.OutPath = def.OutPath
+		if o.Layout == LayoutSplit || o.Layout == LayoutPackages || o.Module != "" {
+			o.OutPath = "."
+		}
+	}
+	if o.PackageName == "" {
+		o.PackageName = def.PackageName
+	}
+	if o.ClientName == "" {
+		o.ClientName = def.ClientName
+	}
+}
+
+func (o Options) validate() error {
+	if o.SpecPath == "" {
+		return errors.New("spec path is required")
+	}
+	if !isIdentifier(o.PackageName) {
+		return fmt.Errorf("invalid package name %q", o.PackageName)
+	}
+	if !isIdentifier(o.ClientName) {
+		return fmt.Errorf("invalid client name %q", o.ClientName)
+	}
+	if strings.ContainsAny(o.Module, " \t\n\\") || strings.HasPrefix(o.Module, "/") || strings.HasSuffix(o.Module, "/") {
+		return fmt.Errorf("invalid module path %q", o.Module)
+	}
+	if err := o.Layout.validate(); err != nil {
+		return err
+	}
+	if err := o.Deprecated.validate(); err != nil {
+		return err
+	}
+	if err := o.Filter.validate(); err != nil {
+		return err
+	}
+	if err := o.Naming.validate(); err != nil {
+		return err
+	}
+	if err := o.Optional.validate(); err != nil {
+		return err
+	}
+	if err := validateStructTags(o.StructTags); err != nil {
+		return err
+	}
+	for id, timeout := range o.Timeouts {
+		if _, err := parseTimeout(timeout); err != nil {
+			return fmt.Errorf("operation %s: %w", id, err)
+		}
+	}
+	for key, typ := range o.TypeMappings {
+		if _, _, err := parseGoType(typ); err != nil {
+			return fmt.Errorf("type mapping %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// GenerateClientCode reads the OpenAPI document at opts.SpecPath and writes
+// the generated client to opts.OutPath.
+func GenerateClientCode(opts Options) error {
+	files, err := Generate(opts)
+	if err != nil {
+		return err
+	}
+	return WriteFiles(opts.OutputDir(), files)
+}
+
+// Generate renders the client described by opts without writing anything.
+// The returned files belong in opts.OutputDir().
+func Generate(opts Options) ([]File, error) {
+	spec, err := LoadSpecs(append([]string{opts.SpecPath}, opts.MergeSpecPaths...)...)
+	if err != nil {
+		return nil, err
+	}
+	return GenerateSpec(spec, opts)
+}
+
+// GenerateSpec renders the client for an already loaded spec;
+// opts.SpecPath and opts.MergeSpecPaths are ignored.
+func GenerateSpec(spec *Spec, opts Options) ([]File, error) {
+	opts.SpecPath = spec.Path
+	opts.applyDefaults()
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := runPrePasses(spec.Document); err != nil {
+		return nil, err
+	}
+
+	if opts.Module != "" {
+		return generateModule(spec, opts)
+	}
+	if opts.Layout == LayoutPackages && opts.ImportPath == "" {
+		importPath, err := importPathOf(opts.OutPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w; set the import path of the output directory explicitly", err)
+		}
+		opts.ImportPath = importPath
+	}
+	return generateClientCode(spec, opts)
+}
+
+// WriteFiles writes files below dir, creating directories as needed. Files
+// whose content is already up to date are left untouched. The build cache
+// of dir is updated afterwards.
+func WriteFiles(dir string, files []File) error {
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if old, err := os.ReadFile(path); err == nil && bytes.Equal(old, f.Content) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
+	// The cache only saves time; failing to update it is not an error.
+	_ = saveCache(dir, files)
+	return nil
+}
+
+// Spec is a parsed OpenAPI document.
+type Spec struct {
+	// Path is the file the document was read from.
+	Path     string
+	Document *v3.Document
+	// Files lists the absolute paths of the document, unless it was read
+	// from stdin, and of every local file it references.
+	Files []string
+	// Hash is "sha256:" and the hex digest of the document and the files it
+	// references, recorded in the generated code.
+	Hash string
+}
+
+// StdioPath stands for standard input as a spec path, and for standard
+// output as the output path of the single layout (see the oasgen command).
+const StdioPath = "-"
+
+// stdinSpecName is the file name a document read from stdin is given.
+const stdinSpecName = "stdin.yaml"
+
+// LoadSpec reads and parses the OpenAPI document at path, or from standard
+// input if path is StdioPath. Relative file references are resolved against
+// the directory of path.
+func LoadSpec(path string) (*Spec, error) {
+	var data []byte
+	var err error
+	if path == StdioPath {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+
+	// A document read from stdin resolves references against the working
+	// directory, as if it were a file there.
+	abs, err := filepath.Abs(path)
+	if path == StdioPath {
+		abs, err = filepath.Abs(stdinSpecName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := libopenapi.NewDocumentWithConfiguration(data, &datamodel.DocumentConfiguration{
+		BasePath:            filepath.Dir(abs),
+		SpecFilePath:        filepath.Base(abs),
+		AllowFileReferences: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+
+	model, err := doc.BuildV3Model()
+	if err != nil && (model == nil || !onlyCircularReferences(err)) {
+		return nil, fmt.Errorf("building OpenAPI v3 model: %w", err)
+	}
+
+	var files, refs []string
+	if path != StdioPath {
+		files = append(files, abs)
+	}
+	if rolodex := doc.GetRolodex(); rolodex != nil {
+		for _, idx := range rolodex.GetIndexes() {
+			p := idx.GetSpecAbsolutePath()
+			if p != "" && p != abs && !strings.Contains(p, "://") && !slices.Contains(refs, p) {
+				refs = append(refs, p)
+			}
+		}
+	}
+	files = append(files, refs...)
+	hash, err := hashSpec(data, refs)
+	if err != nil {
+		return nil, fmt.Errorf("hashing spec: %w", err)
+	}
+	return &Spec{Path: path, Document: &model.Model, Files: files, Hash: hash}, nil
+}
+
+// onlyCircularReferences reports whether err only holds circular reference
+// errors, such as a schema requiring a property of its own type. The model
+// is complete then, and the generator breaks the cycles with pointers.
+func onlyCircularReferences(err error) bool {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, err := range errs {
+		var refErr *index.ResolvingError
+		if !errors.As(err, &refErr) || refErr.CircularReference == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// generator holds the state of a single generation run.
+type generator struct {
+	opts Options
+	doc  *v3.Document
+	// imports records non-standard-library packages referenced by mapped
+	// types, so the import resolver can find them.
+	imports map[string]struct{}
+	// qualifier is prepended to references to component schemas, for code
+	// that lives outside the package declaring the models.
+	qualifier string
+	// at names the schema being converted by goType, for log messages, and
+	// typeAt is the Go type name an inline enum found there is given.
+	at     string
+	typeAt string
+	// pending holds the inline enum types declared by goType, to be
+	// emitted after the type that uses them.
+	pending []modelData
+	// degradations collects what g.degraded reported while building the
+	// current operation.
+	degradations []string
+	// models holds the package-level identifiers taken by the generated
+	// code: the names of models and of their enum constants.
+	models map[string]bool
+	// schemaTypes maps component schema names to their type names, once
+	// buildModels has resolved collisions.
+	schemaTypes map[string]string
+	// methods holds the names of the generated client methods.
+	methods map[string]bool
+	// structs maps the names of the struct models to their fields, once
+	// buildModels is done, for the parameters of operations.
+	structs map[string][]fieldData
+	// inlineTypes maps the nodes of the inline schemas of models to the
+	// types declared for them, once buildModels is done.
+	inlineTypes map[*yaml.Node]string
+	// initialisms is the set of Naming.Initialisms, built by goName.
+	initialisms map[string]bool
+	// usesDate and usesDecimal record that the generated Date and Decimal
+	// types are referenced.
+	usesDate    bool
+	usesDecimal bool
+	// usesProblem, usesEvents, usesLines, usesArrays, usesCSV, usesFiles,
+	// usesRanges, usesPollers and usesLinks record that the generated
+	// ProblemDetails type, the types of server-sent events, the JSONLines
+	// and JSONArray functions, the CSV functions, the functions saving
+	// downloads, those of range requests, the Poller type and the types of
+	// Link headers are referenced, and usesMediaTypes the hasMediaType
+	// function.
+	usesProblem    bool
+	usesEvents     bool
+	usesLines      bool
+	usesArrays     bool
+	usesCSV        bool
+	usesFiles      bool
+	usesRanges     bool
+	usesPollers    bool
+	usesLinks      bool
+	usesMediaTypes bool
+	// xml reports whether the document has XML bodies, whose struct
+	// fields then get xml tags, and codecs whether it has bodies of a
+	// codecMediaTypes, for which the client gets the Codec type.
+	xml    bool
+	codecs bool
+	// security are the security schemes the client has options for.
+	security []securitySchemeData
+	// responseSchemas are the schemas WithResponseValidation checks JSON
+	// response bodies against, and checkedSchemas the keys among them.
+	responseSchemas []responseSchemaData
+	checkedSchemas  map[string]bool
+	// dir is prepended to the names of the generated files.
+	dir string
+	// cache is the build cache of the output directory, or nil.
+	cache *buildCache
+	// header is the comment at the top of every generated Go file.
+	header     string
+	provenance provenance
+}
+
+// fileData is the root value passed to the "file" template. A file holds
+// some models, optionally the client type, and some operations.
+type fileData struct {
+	// Header is the comment at the top of the file.
+	Header      string
+	PackageName string
+	ClientName  string
+	Models      []modelData
+	Client      bool
+	// Core marks the client of the core package in the packages layout,
+	// which is shared with the per-tag packages.
+	Core bool
+	// TagClient marks a per-tag package in the packages layout, whose
+	// client type wraps the core client.
+	TagClient bool
+	// Provenance is emitted as constants alongside the client type.
+	Provenance provenance
+	// Server is the default server of the client, or nil, and
+	// ServerVariables the variables of all servers.
+	Server          *serverData
+	ServerVariables []serverVariable
+	// Optional selects the helper types emitted alongside the client type.
+	// Date, Decimal and Problem add the Date, Decimal and ProblemDetails
+	// types, Events the ServerSentEvent type and the ServerSentEvents
+	// function, Lines the JSONLines function, Arrays the JSONArray
+	// function, CSV the ReadCSV and CSVRows
+	// functions, Files the SuggestedFilename and SaveFile functions, Ranges
+	// the ContentRange, WriteRange and DownloadChunks functions, Pollers
+	// the Poller type, Links the WebLink type and the ParseLinks function
+	// and Validation the ValidationError type.
+	Optional   OptionalStrategy
+	Date       bool
+	Decimal    bool
+	Problem    bool
+	Events     bool
+	Lines      bool
+	Arrays     bool
+	CSV        bool
+	Files      bool
+	Ranges     bool
+	Pollers    bool
+	Links      bool
+	Validation bool
+	// XML and MediaTypes add the isXML and hasMediaType functions next to
+	// the client, or to the client of a per-tag package, and Codecs the
+	// Codec type and the codec method.
+	XML        bool
+	MediaTypes bool
+	Codecs     bool
+	// ResponseSchemas are the entries of the responseSchemas map of the
+	// client, which WithResponseValidation checks response bodies
+	// against, and ResponseValidation adds the validateResponse method
+	// to the client of a per-tag package.
+	ResponseSchemas    []responseSchemaData
+	ResponseValidation bool
+	// SecuritySchemes are the schemes the client has options for, and
+	// DocumentSecurity the Go expression of the security requirements of
+	// the document, which apply to the operations without their own, if
+	// it has any.
+	// BearerSchemes are the names of those of bearer tokens, and
+	// ClientCredentials adds the token source of the OAuth 2.0 client
+	// credentials flow.
+	SecuritySchemes   []securitySchemeData
+	DocumentSecurity  string
+	BearerSchemes     []string
+	ClientCredentials bool
+	Operations        []operationData
+
+	// imports lists additional import paths the file may reference.
+	imports []string
+}
+
+func generateClientCode(spec *Spec, opts Options) ([]File, error) {
+	tmpl, err := loadTemplates(opts.TemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	prov := newProvenance(spec)
+	header, err := loadHeader(opts.HeaderFile, prov)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		opts:       opts,
+		doc:        spec.Document,
+		imports:    map[string]struct{}{},
+		models:     map[string]bool{},
+		methods:    map[string]bool{},
+		xml:        documentUsesXML(spec.Document),
+		codecs:     documentUsesCodecs(spec.Document),
+		header:     header,
+		provenance: prov,
+	}
+	if !opts.NoCache {
+		g.cache = openCache(opts.OutputDir(), opts.TemplatesDir)
+	}
+	g.security = g.buildSecuritySchemes()
+
+	models, err := g.buildModels()
+	if err != nil {
+		return nil, err
+	}
+	operations, err := g.buildOperations()
+	if err != nil {
+		return nil, err
+	}
+	g.buildValidation(models, operations)
+	g.buildErrors(models, operations)
+	return g.render(tmpl, models, operations)
+}
+
+// render executes the "file" template for every file of the layout. Files
+// are rendered and formatted concurrently, one worker per CPU, and returned
+// in layout order; on failure the error of the first failing file in that
+// order is returned.
+func (g *generator) render(tmpl *template.Template, models []modelData, operations []operationData) ([]File, error) {
+	type job struct {
+		name string
+		data fileData
+	}
+	var jobs []job
+	for name, data := range g.layoutFiles(models, operations) {
+		jobs = append(jobs, job{path.Join(g.dir, name), data})
+	}
+	imports := slices.Sorted(maps.Keys(g.imports))
+
+	files := make([]File, len(jobs))
+	errs := make([]error, len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				j := jobs[i]
+				files[i].Name = j.name
+				if g.cache != nil {
+					files[i].inputHash = g.cache.inputHash(j.name, j.data, imports)
+					if content, ok := g.cache.lookup(j.name, files[i].inputHash); ok {
+						files[i].Content = content
+						continue
+					}
+				}
+				files[i].Content, errs[i] = renderFile(tmpl, j.name, j.data, append(slices.Clip(imports), j.data.imports...))
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// renderFile executes the "file" template with data and formats the result.
+func renderFile(tmpl *template.Template, name string, data fileData, imports []string) ([]byte, error) {
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&b, "file", data); err != nil {
+		return nil, fmt.Errorf("executing templates for %s: %w", name, err)
+	}
+	code, err := runPostPasses(name, b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if code, err = formatSource(code, imports); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return code, nil
+}
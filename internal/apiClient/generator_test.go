@@ -0,0 +1,73 @@
+package apiClient
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// generateFile renders the client of the OpenAPI document spec, given as
+// YAML, into a single file of package client and returns it. The options
+// are the defaults, changed by each of configure in turn.
+func generateFile(t *testing.T, spec string, configure ...func(*Options)) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{
+		SpecPath:    path,
+		OutPath:     filepath.Join(dir, "client", "client.go"),
+		PackageName: "client",
+		NoCache:     true,
+	}
+	for _, c := range configure {
+		c(&opts)
+	}
+	files, err := Generate(opts)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Generate returned %d files, want 1", len(files))
+	}
+	return files[0].Content
+}
+
+// runGenerated renders the client of spec as the package client of a
+// scratch module example.com/gen, and runs the program main of the module,
+// which imports it. It returns what the program printed. configure changes
+// the options as for generateFile.
+func runGenerated(t *testing.T, spec, main string, configure ...func(*Options)) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds and runs a generated client")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	dir := t.TempDir()
+	write := func(name string, content []byte) {
+		t.Helper()
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", []byte("module example.com/gen\n\ngo 1.26\n"))
+	write("client/client.go", generateFile(t, spec, configure...))
+	write("main.go", []byte(main))
+	cmd := exec.Command(gobin, "run", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run: %v\n%s", err, out)
+	}
+	return string(out)
+}
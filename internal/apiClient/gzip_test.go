@@ -0,0 +1,112 @@
+package apiClient
+
+import "testing"
+
+const gzipSpec = `
+openapi: 3.0.3
+info: {title: gzip, version: "1"}
+paths:
+  /echo:
+    post:
+      operationId: echo
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const gzipMain = `package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+		data, _ := io.ReadAll(body)
+		fmt.Printf("server Content-Encoding=%q Accept-Encoding=%q %s\n", r.Header.Get("Content-Encoding"), r.Header.Get("Accept-Encoding"), data)
+		w.Header().Set("Content-Type", "application/json")
+		switch accept := r.Header.Get("Accept-Encoding"); {
+		case strings.Contains(accept, "x-reverse"):
+			w.Header().Set("Content-Encoding", "x-reverse")
+			slices.Reverse(data)
+		case strings.Contains(accept, "gzip"):
+			w.Header().Set("Content-Encoding", "gzip")
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			zw.Write(data)
+			zw.Close()
+			data = buf.Bytes()
+		}
+		w.Write(data)
+	}))
+	defer srv.Close()
+	reverse := func(r io.Reader) (io.ReadCloser, error) {
+		data, err := io.ReadAll(r)
+		slices.Reverse(data)
+		return io.NopCloser(bytes.NewReader(data)), err
+	}
+	ctx := context.Background()
+	for _, opts := range [][]client.ClientOption{
+		{client.WithRequestCompression(1), client.WithResponseDecompression(nil)},
+		{client.WithRequestCompression(1000)},
+		{client.WithResponseDecompression(map[string]client.ContentDecoder{"x-reverse": reverse})},
+	} {
+		c := client.NewClient(srv.URL, opts...)
+		resp, err := c.Echo(ctx, client.Pet{Name: "rex"})
+		if err != nil {
+			fmt.Println("error", err)
+			continue
+		}
+		fmt.Printf("client Content-Encoding=%q %s\n", resp.HTTPResponse.Header.Get("Content-Encoding"), resp.JSON200.Name)
+	}
+}
+`
+
+// TestCompression checks that request bodies are compressed with gzip from
+// the size set, and that responses are decompressed with gzip, or with the
+// decoder of their coding, once their codings are asked for.
+func TestCompression(t *testing.T) {
+	got := runGenerated(t, gzipSpec, gzipMain)
+	want := "server Content-Encoding=\"gzip\" Accept-Encoding=\"deflate, gzip\" {\"name\":\"rex\"}\n" +
+		"client Content-Encoding=\"\" rex\n" +
+		"server Content-Encoding=\"\" Accept-Encoding=\"gzip\" {\"name\":\"rex\"}\n" +
+		"client Content-Encoding=\"\" rex\n" +
+		"server Content-Encoding=\"\" Accept-Encoding=\"deflate, gzip, x-reverse\" {\"name\":\"rex\"}\n" +
+		"client Content-Encoding=\"\" rex\n"
+	if got != want {
+		t.Errorf("round trips:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package apiClient
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// generatedRE matches the comment marking generated files, see
+// https://go.dev/s/generatedcode.
+var generatedRE = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// headerData is the value the header file is executed with.
+type headerData struct {
+	// Year is the current year, for copyright lines.
+	Year int
+	// Date is the current date as YYYY-MM-DD. Using it makes the output
+	// change daily, which defeats -diff checks.
+	Date string
+}
+
+// loadHeader reads the header file at path and returns the comment to put
+// at the top of every generated Go file. The file is a text/template
+// executed with headerData; lines not already written as comments are
+// turned into "//" comments, and the generated-code marker of prov is
+// appended unless the file contains one.
+func loadHeader(path string, prov provenance) (string, error) {
+	if path == "" {
+		return prov.marker(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading header file: %w", err)
+	}
+	tmpl, err := template.New(path).Parse(string(data))
+	if err != nil {
+		return "", fmt.Errorf("parsing header file: %w", err)
+	}
+	now := time.Now()
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, headerData{Year: now.Year(), Date: now.Format(time.DateOnly)}); err != nil {
+		return "", fmt.Errorf("executing header file: %w", err)
+	}
+
+	header := strings.TrimSpace(b.String())
+	if !strings.HasPrefix(header, "//") && !strings.HasPrefix(header, "/*") {
+		lines := strings.Split(header, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("// "+line, " ")
+		}
+		header = strings.Join(lines, "\n")
+	}
+	if !generatedRE.MatchString(header) {
+		header += "\n\n" + prov.marker()
+	}
+	return header, nil
+}
@@ -0,0 +1,57 @@
+package apiClient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// RunPostHooks runs opts.PostHooks, in order, after files have been written
+// to opts.OutputDir(), and fails on the first command that does not succeed.
+//
+// A hook is a command line split on white space; no shell is involved. It
+// runs in the output directory. The argument "{files}" is replaced by the
+// names of the generated files relative to it, and "{dir}" by the output
+// directory itself, e.g. "goimports -w {files}" or "go vet ./...".
+func RunPostHooks(ctx context.Context, opts Options, files []File) error {
+	dir := opts.OutputDir()
+	for _, hook := range opts.PostHooks {
+		args := expandHook(hook, dir, files)
+		if len(args) == 0 {
+			continue
+		}
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = dir
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		if err := cmd.Run(); err != nil {
+			msg := strings.TrimSpace(out.String())
+			if msg == "" {
+				return fmt.Errorf("post hook %q: %w", hook, err)
+			}
+			return fmt.Errorf("post hook %q: %w\n%s", hook, err, msg)
+		}
+	}
+	return nil
+}
+
+func expandHook(hook, dir string, files []File) []string {
+	var args []string
+	for _, f := range strings.Fields(hook) {
+		switch f {
+		case "{files}":
+			for _, file := range files {
+				args = append(args, filepath.FromSlash(file.Name))
+			}
+		case "{dir}":
+			args = append(args, dir)
+		default:
+			args = append(args, f)
+		}
+	}
+	return args
+}
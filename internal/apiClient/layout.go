@@ -0,0 +1,300 @@
+package apiClient
+
+import (
+	"fmt"
+	"go/token"
+	"iter"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Layout selects how generated code is split into files.
+type Layout string
+
+const (
+	// LayoutSingle writes everything to the single file named by
+	// Options.OutPath.
+	LayoutSingle Layout = "single"
+	// LayoutSplit treats Options.OutPath as a directory and writes models to
+	// models_gen.go, the client type to client_gen.go and the operations of
+	// each tag to <tag>_gen.go.
+	LayoutSplit Layout = "split"
+	// LayoutPackages treats Options.OutPath as a directory and writes the
+	// models and the shared transport to a "core" package, plus one
+	// package per tag whose client wraps the core client. Untagged
+	// operations stay on the core client.
+	LayoutPackages Layout = "packages"
+)
+
+const (
+	modelsFile    = "models_gen.go"
+	clientFile    = "client_gen.go"
+	untaggedFile  = "operations_gen.go"
+	genFileSuffix = "_gen.go"
+	corePackage   = "core"
+)
+
+// File is a generated Go source file.
+type File struct {
+	// Name is the slash-separated path of the file relative to the output
+	// directory.
+	Name    string
+	Content []byte
+
+	// inputHash identifies what the file was rendered from, for the build
+	// cache; it is empty for files that are not cached.
+	inputHash string
+}
+
+func (l Layout) validate() error {
+	switch l {
+	case "", LayoutSingle, LayoutSplit, LayoutPackages:
+		return nil
+	}
+	return fmt.Errorf("invalid layout %q: want %s, %s or %s", l, LayoutSingle, LayoutSplit, LayoutPackages)
+}
+
+// OutputDir returns the directory generated files are written to.
+func (o Options) OutputDir() string {
+	o.applyDefaults()
+	if o.Layout == LayoutSplit || o.Layout == LayoutPackages || o.Module != "" {
+		return o.OutPath
+	}
+	return filepath.Dir(o.OutPath)
+}
+
+// layoutFiles yields the template data of every file to generate, keyed by
+// file name, in a stable order.
+func (g *generator) layoutFiles(models []modelData, ops []operationData) iter.Seq2[string, fileData] {
+	return func(yield func(string, fileData) bool) {
+		base := fileData{
+			Header:      g.header,
+			PackageName: g.opts.PackageName,
+			ClientName:  g.opts.ClientName,
+		}
+
+		switch g.opts.Layout {
+		case LayoutSplit:
+		case LayoutPackages:
+			g.layoutPackages(models, ops)(yield)
+			return
+		default:
+			all := base
+			all.Models = models
+			all.Client = true
+			all.Optional = g.opts.Optional
+			all.Date = g.usesDate
+			all.Decimal = g.usesDecimal
+			all.Problem = g.usesProblem
+			all.Events = g.usesEvents
+			all.Lines = g.usesLines
+			all.Arrays = g.usesArrays
+			all.CSV = g.usesCSV
+			all.Files = g.usesFiles
+			all.Ranges = g.usesRanges
+			all.Pollers = g.usesPollers
+			all.Links = g.usesLinks
+			all.XML = g.xml
+			all.Codecs = g.codecs
+			all.ResponseSchemas = g.responseSchemas
+			all.ResponseValidation = len(g.responseSchemas) > 0
+			all.SecuritySchemes = g.security
+			all.DocumentSecurity = securityLiteral(g.doc.Security)
+			all.BearerSchemes = bearerSchemes(g.security)
+			all.ClientCredentials = usesClientCredentials(g.security)
+			all.MediaTypes = g.usesMediaTypes
+			all.Validation = g.validates()
+			all.Provenance = g.provenance
+			all.Server = g.defaultServer()
+			all.ServerVariables = g.serverVariables()
+			all.Operations = ops
+			yield(filepath.Base(g.opts.OutPath), all)
+			return
+		}
+
+		if len(models) > 0 {
+			m := base
+			m.Models = models
+			if !yield(modelsFile, m) {
+				return
+			}
+		}
+		c := base
+		c.Client = true
+		c.Optional = g.opts.Optional
+		c.Date = g.usesDate
+		c.Decimal = g.usesDecimal
+		c.Problem = g.usesProblem
+		c.Events = g.usesEvents
+		c.Lines = g.usesLines
+		c.Arrays = g.usesArrays
+		c.CSV = g.usesCSV
+		c.Files = g.usesFiles
+		c.Ranges = g.usesRanges
+		c.Pollers = g.usesPollers
+		c.Links = g.usesLinks
+		c.XML = g.xml
+		c.Codecs = g.codecs
+		c.ResponseSchemas = g.responseSchemas
+		c.ResponseValidation = len(g.responseSchemas) > 0
+		c.SecuritySchemes = g.security
+		c.DocumentSecurity = securityLiteral(g.doc.Security)
+		c.BearerSchemes = bearerSchemes(g.security)
+		c.ClientCredentials = usesClientCredentials(g.security)
+		c.MediaTypes = g.usesMediaTypes
+		c.Validation = g.validates()
+		c.Provenance = g.provenance
+		c.Server = g.defaultServer()
+		c.ServerVariables = g.serverVariables()
+		if !yield(clientFile, c) {
+			return
+		}
+
+		var names []string
+		byFile := map[string][]operationData{}
+		for _, op := range ops {
+			name := tagFileName(op.Tag)
+			if _, ok := byFile[name]; !ok {
+				names = append(names, name)
+			}
+			byFile[name] = append(byFile[name], op)
+		}
+		for _, name := range names {
+			f := base
+			f.Operations = byFile[name]
+			if !yield(name, f) {
+				return
+			}
+		}
+	}
+}
+
+// layoutPackages yields the files of the packages layout: the core package
+// followed by one package per tag.
+func (g *generator) layoutPackages(models []modelData, ops []operationData) iter.Seq2[string, fileData] {
+	return func(yield func(string, fileData) bool) {
+		var pkgs []string
+		byPkg := map[string][]operationData{}
+		for _, op := range ops {
+			pkg := tagPackageName(op.Tag)
+			if _, ok := byPkg[pkg]; !ok {
+				pkgs = append(pkgs, pkg)
+			}
+			byPkg[pkg] = append(byPkg[pkg], op)
+		}
+
+		core := fileData{
+			Header:      g.header,
+			PackageName: corePackage,
+			ClientName:  g.opts.ClientName,
+		}
+		if len(models) > 0 {
+			m := core
+			m.Models = models
+			if !yield(corePackage+"/"+modelsFile, m) {
+				return
+			}
+		}
+		c := core
+		c.Client = true
+		c.Optional = g.opts.Optional
+		c.Date = g.usesDate
+		c.Decimal = g.usesDecimal
+		c.Problem = g.usesProblem
+		c.Events = g.usesEvents
+		c.Lines = g.usesLines
+		c.Arrays = g.usesArrays
+		c.CSV = g.usesCSV
+		c.Files = g.usesFiles
+		c.Ranges = g.usesRanges
+		c.Pollers = g.usesPollers
+		c.Links = g.usesLinks
+		c.XML = g.xml
+		c.Codecs = g.codecs
+		c.ResponseSchemas = g.responseSchemas
+		c.ResponseValidation = len(g.responseSchemas) > 0
+		c.SecuritySchemes = g.security
+		c.DocumentSecurity = securityLiteral(g.doc.Security)
+		c.BearerSchemes = bearerSchemes(g.security)
+		c.ClientCredentials = usesClientCredentials(g.security)
+		c.MediaTypes = g.usesMediaTypes
+		c.Validation = g.validates()
+		c.Core = true
+		c.Provenance = g.provenance
+		c.Server = g.defaultServer()
+		c.ServerVariables = g.serverVariables()
+		c.Operations = byPkg[corePackage]
+		if !yield(corePackage+"/"+clientFile, c) {
+			return
+		}
+
+		for _, pkg := range pkgs {
+			if pkg == corePackage {
+				continue
+			}
+			f := fileData{
+				Header:             g.header,
+				PackageName:        pkg,
+				ClientName:         g.opts.ClientName,
+				TagClient:          true,
+				XML:                g.xml,
+				Codecs:             g.codecs,
+				MediaTypes:         g.usesMediaTypes,
+				Operations:         byPkg[pkg],
+				ResponseValidation: len(g.responseSchemas) > 0,
+				imports:            []string{path.Join(g.opts.ImportPath, corePackage)},
+			}
+			if !yield(pkg+"/"+pkg+genFileSuffix, f) {
+				return
+			}
+		}
+	}
+}
+
+// tagPackageName returns the package holding the operations of tag in the
+// packages layout. Untagged operations belong to the core package.
+func tagPackageName(tag string) string {
+	name := strings.ToLower(toGoName(tag))
+	switch {
+	case name == "":
+		return corePackage
+	case name == corePackage, token.IsKeyword(name):
+		return name + "api"
+	}
+	return name
+}
+
+// tagFileName returns the file holding the operations of tag, keeping clear
+// of the models and client file names.
+func tagFileName(tag string) string {
+	snake := toSnakeCase(tag)
+	if snake == "" {
+		return untaggedFile
+	}
+	name := snake + genFileSuffix
+	switch name {
+	case modelsFile, clientFile, untaggedFile:
+		name = snake + "_operations" + genFileSuffix
+	}
+	return name
+}
+
+// toSnakeCase converts a spec name such as "Pet Store" or "petStore" to
+// "pet_store".
+func toSnakeCase(name string) string {
+	runes := []rune(toGoName(name))
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
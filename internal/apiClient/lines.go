@@ -0,0 +1,59 @@
+package apiClient
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// lineMediaTypes are the media types of streams of JSON values, one per
+// line: NDJSON and JSON Lines.
+var lineMediaTypes = []string{"application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines", "application/jsonlines"}
+
+// linesFunc is the name of the generic function decoding the lines of a
+// stream, emitted alongside the client.
+const linesFunc = "JSONLines"
+
+// buildLines adds to data the method yielding the values of an NDJSON or
+// JSON Lines response of op that is not an error, such as
+// ExportPetsStream, decoding each line into the type its schema, or the
+// items of an array schema, describe. Values without a schema are
+// json.RawMessage.
+func (g *generator) buildLines(data *operationData, op *v3.Operation) {
+	mt := resultMediaType(op, lineMediaTypes...)
+	if mt == nil {
+		return
+	}
+	if !g.usesLines && g.models[linesFunc] {
+		g.log().Warn("function name for JSON lines is taken: no stream method", "operation", data.Name, "function", linesFunc)
+		return
+	}
+	g.usesLines = true
+	g.models[linesFunc] = true
+
+	data.Lines = data.Name + "Stream"
+	if free := freeName(data.Lines, g.methods); free != data.Lines {
+		g.renamed("operation", data.Method+" "+data.Path+" stream", data.Lines, free)
+		data.Lines = free
+	}
+	g.methods[data.Lines] = true
+	data.LinesFunc = g.qualifier + linesFunc
+	data.LineType = rawJSONType
+	if schema := lineSchema(mt); schema != nil {
+		data.LineType = g.itemType(data, schema, "Item", "a line of the response")
+	}
+}
+
+// lineSchema returns the schema of a line of mt: its itemSchema, the
+// items of an array schema or else the schema itself.
+func lineSchema(mt *v3.MediaType) *base.SchemaProxy {
+	if mt.ItemSchema != nil {
+		return mt.ItemSchema
+	}
+	if mt.Schema == nil {
+		return nil
+	}
+	if schema := mt.Schema.Schema(); schema != nil && schemaType(schema) == "array" && schema.Items != nil && schema.Items.IsA() {
+		return schema.Items.A
+	}
+	return mt.Schema
+}
@@ -0,0 +1,85 @@
+package apiClient
+
+import "testing"
+
+const linesSpec = `
+openapi: 3.0.3
+info: {title: lines, version: "1"}
+paths:
+  /pets/export:
+    get:
+      operationId: exportPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/x-ndjson:
+              schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+  /pets/watch:
+    get:
+      operationId: watchPets
+      responses:
+        "200":
+          description: ok
+          content:
+            text/event-stream:
+              schema: {$ref: '#/components/schemas/Pet'}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const linesMain = `package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pets/watch" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"name\":\"b\",\"age\":2}\n\n")
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprint(w, "{\"name\":\"a\",\"age\":1}\n")
+	}))
+	defer srv.Close()
+	codec := client.NewCodec(json.Marshal, func(data []byte, v any) error {
+		fmt.Print("codec ")
+		return json.Unmarshal(data, v)
+	})
+	ctx := context.Background()
+	for _, opt := range []client.ClientOption{client.WithStrictDecoding(false), client.WithStrictDecoding(true), client.WithJSONCodec(codec)} {
+		c := client.NewClient(srv.URL, opt)
+		for pet, err := range c.ExportPetsStream(ctx) {
+			fmt.Println(pet.Name, err != nil)
+		}
+		for event, err := range c.WatchPetsEvents(ctx) {
+			fmt.Println(event.Data.Name, err != nil)
+			break
+		}
+	}
+}
+`
+
+// TestStreamDecoding checks that the lines of a JSON Lines response and the
+// data of server-sent events are decoded with the codec of the client, as
+// strictly as it is set to.
+func TestStreamDecoding(t *testing.T) {
+	got := runGenerated(t, linesSpec, linesMain)
+	want := "a false\nb false\na true\nb true\ncodec a false\ncodec b false\n"
+	if got != want {
+		t.Errorf("decoded values:\n%s\nwant:\n%s", got, want)
+	}
+}
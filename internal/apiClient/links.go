@@ -0,0 +1,67 @@
+package apiClient
+
+import (
+	"net/http"
+	"net/textproto"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// webLinkType, webLinksType, parseLinksFunc and errNoLink are the names of
+// the types of the links of a Link header, of the function parsing them
+// and of the error of following a missing one, emitted alongside the
+// client.
+const (
+	webLinkType    = "WebLink"
+	webLinksType   = "WebLinks"
+	parseLinksFunc = "ParseLinks"
+	errNoLink      = "ErrNoLink"
+)
+
+// buildLinks adds to data, if it is a GET and a response of op that is not
+// an error declares a Link header, the Links method of its result type and
+// the methods following the links of the header, such as ListPetsFollow,
+// ListPetsNext and ListPetsPrev.
+func (g *generator) buildLinks(data *operationData, op *v3.Operation) {
+	if data.Method != http.MethodGet || op.Responses == nil {
+		return
+	}
+	var declared bool
+	for code, resp := range op.Responses.Codes.FromOldest() {
+		if isErrorStatus(code) || resp.Headers == nil {
+			continue
+		}
+		for name := range resp.Headers.KeysFromOldest() {
+			declared = declared || textproto.CanonicalMIMEHeaderKey(name) == "Link"
+		}
+	}
+	if !declared {
+		return
+	}
+	if !g.usesLinks && (g.models[webLinkType] || g.models[webLinksType] || g.models[parseLinksFunc] || g.models[errNoLink]) {
+		g.log().Warn("type name for Link headers is taken: no link methods", "operation", data.Name, "type", webLinkType)
+		return
+	}
+	g.usesLinks = true
+	g.models[webLinkType], g.models[webLinksType], g.models[parseLinksFunc], g.models[errNoLink] = true, true, true, true
+	data.LinksType, data.ParseLinksFunc, data.ErrNoLink = g.qualifier+webLinksType, g.qualifier+parseLinksFunc, g.qualifier+errNoLink
+
+	for _, m := range []struct {
+		name   *string
+		suffix string
+	}{{&data.Follow, "Follow"}, {&data.Next, "Next"}, {&data.Prev, "Prev"}} {
+		name := data.Name + m.suffix
+		if free := freeName(name, g.methods); free != name {
+			g.renamed("operation", data.Method+" "+data.Path+" "+m.suffix, name, free)
+			name = free
+		}
+		g.methods[name] = true
+		*m.name = name
+	}
+}
+
+// Followed returns o as the data of its Follow method.
+func (o operationData) Followed() operationData {
+	o.Following = true
+	return o
+}
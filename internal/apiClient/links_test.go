@@ -0,0 +1,76 @@
+package apiClient
+
+import "testing"
+
+const linksSpec = `
+openapi: 3.0.3
+info: {title: links, version: "1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - {name: page, in: query, required: true, schema: {type: integer}}
+        - {name: X-Tenant, in: header, required: true, schema: {type: string}}
+        - {name: session, in: cookie, schema: {type: string}}
+      responses:
+        "200":
+          description: ok
+          headers:
+            Link: {schema: {type: string}}
+          content:
+            application/json:
+              schema: {type: array, items: {type: string}}
+`
+
+const linksMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		var session string
+		if cookie, err := r.Cookie("session"); err == nil {
+			session = cookie.Value
+		}
+		fmt.Println(page, r.Header.Get("X-Tenant"), session, r.Header.Get("X-Edited"))
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf("</pets?page=%d>; rel=\"next\"", page+1))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "[]")
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL, client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Edited", "yes")
+		return nil
+	}))
+	ctx := context.Background()
+	session := "s1"
+	page, err := c.ListPets(ctx, client.ListPetsParams{Page: 1, XTenant: "acme", Session: &session})
+	for err == nil {
+		page, err = c.ListPetsNext(ctx, page)
+	}
+	fmt.Println(err)
+}
+`
+
+// TestFollowLinks checks that the requests following the links of a Link
+// header send the header and cookie parameters of the first one again, and
+// go through the request editors of the client.
+func TestFollowLinks(t *testing.T) {
+	got := runGenerated(t, linksSpec, linksMain)
+	want := "1 acme s1 yes\n2 acme s1 yes\n3 acme s1 yes\nno link of the relation\n"
+	if got != want {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, want)
+	}
+}
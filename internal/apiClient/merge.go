@@ -0,0 +1,225 @@
+package apiClient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// LoadSpecs loads the document at paths[0] and merges the documents at the
+// remaining paths into it, see MergeSpecs.
+func LoadSpecs(paths ...string) (*Spec, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("spec path is required")
+	}
+	if n := len(slices.DeleteFunc(slices.Clone(paths), func(p string) bool { return p != StdioPath })); n > 1 {
+		return nil, errors.New("only one spec can be read from stdin")
+	}
+	specs := make([]*Spec, len(paths))
+	for i, path := range paths {
+		spec, err := LoadSpec(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		specs[i] = spec
+	}
+	return MergeSpecs(specs...)
+}
+
+// MergeSpecs merges the paths, components and tags of specs[1:] into
+// specs[0], which keeps its info and servers, and returns it.
+//
+// The same path may appear in several documents as long as each method is
+// defined once. Components of the same name must be identical, so that
+// documents can share common schemas; operationIds must be unique. Any
+// collision is an error naming both documents.
+func MergeSpecs(specs ...*Spec) (*Spec, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("no spec to merge")
+	}
+	dst := specs[0]
+	if len(specs) == 1 {
+		return dst, nil
+	}
+
+	m := &merger{
+		doc:          dst.Document,
+		pathOrigins:  map[string]string{},
+		compOrigins:  map[string]string{},
+		opIDOrigins:  map[string]string{},
+		mergedHashes: []string{dst.Hash},
+	}
+	m.record(dst.Path, dst.Document)
+	for _, src := range specs[1:] {
+		if err := m.merge(src); err != nil {
+			return nil, err
+		}
+		for _, f := range src.Files {
+			if !slices.Contains(dst.Files, f) {
+				dst.Files = append(dst.Files, f)
+			}
+		}
+	}
+
+	hash, err := hashSpec([]byte(strings.Join(m.mergedHashes, "\n")), nil)
+	if err != nil {
+		return nil, err
+	}
+	dst.Hash = hash
+	return dst, nil
+}
+
+// merger accumulates documents into doc, remembering which document each
+// path, component and operationId came from.
+type merger struct {
+	doc          *v3.Document
+	pathOrigins  map[string]string
+	compOrigins  map[string]string
+	opIDOrigins  map[string]string
+	mergedHashes []string
+}
+
+// record notes the origin of everything in doc, which is already part of
+// the result.
+func (m *merger) record(origin string, doc *v3.Document) {
+	if doc.Paths != nil {
+		for path, item := range doc.Paths.PathItems.FromOldest() {
+			for method, op := range pathOperations(item) {
+				m.pathOrigins[method+" "+path] = origin
+				if op.OperationId != "" {
+					m.opIDOrigins[op.OperationId] = origin
+				}
+			}
+		}
+	}
+	if c := doc.Components; c != nil {
+		recordNames(m.compOrigins, "schema", c.Schemas, origin)
+		recordNames(m.compOrigins, "response", c.Responses, origin)
+		recordNames(m.compOrigins, "parameter", c.Parameters, origin)
+		recordNames(m.compOrigins, "request body", c.RequestBodies, origin)
+		recordNames(m.compOrigins, "header", c.Headers, origin)
+		recordNames(m.compOrigins, "security scheme", c.SecuritySchemes, origin)
+	}
+}
+
+func (m *merger) merge(src *Spec) error {
+	doc := src.Document
+	if doc.Paths != nil {
+		if m.doc.Paths == nil {
+			m.doc.Paths = &v3.Paths{PathItems: orderedmap.New[string, *v3.PathItem]()}
+		}
+		for path, item := range doc.Paths.PathItems.FromOldest() {
+			if err := m.mergePath(src.Path, path, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c := doc.Components; c != nil {
+		if m.doc.Components == nil {
+			m.doc.Components = &v3.Components{}
+		}
+		dc := m.doc.Components
+		for _, err := range []error{
+			mergeComponents(m.compOrigins, "schema", &dc.Schemas, c.Schemas, src.Path),
+			mergeComponents(m.compOrigins, "response", &dc.Responses, c.Responses, src.Path),
+			mergeComponents(m.compOrigins, "parameter", &dc.Parameters, c.Parameters, src.Path),
+			mergeComponents(m.compOrigins, "request body", &dc.RequestBodies, c.RequestBodies, src.Path),
+			mergeComponents(m.compOrigins, "header", &dc.Headers, c.Headers, src.Path),
+			mergeComponents(m.compOrigins, "security scheme", &dc.SecuritySchemes, c.SecuritySchemes, src.Path),
+		} {
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, tag := range doc.Tags {
+		if !slices.ContainsFunc(m.doc.Tags, func(t *base.Tag) bool { return t.Name == tag.Name }) {
+			m.doc.Tags = append(m.doc.Tags, tag)
+		}
+	}
+	if len(m.doc.Servers) == 0 {
+		m.doc.Servers = doc.Servers
+	}
+	m.mergedHashes = append(m.mergedHashes, src.Hash)
+	return nil
+}
+
+// mergePath adds the operations of item to the path of the same name.
+func (m *merger) mergePath(origin, path string, item *v3.PathItem) error {
+	for method, op := range pathOperations(item) {
+		if prev, ok := m.pathOrigins[method+" "+path]; ok {
+			return fmt.Errorf("%s %s is defined in both %s and %s", method, path, prev, origin)
+		}
+		if op.OperationId != "" {
+			if prev, ok := m.opIDOrigins[op.OperationId]; ok {
+				return fmt.Errorf("operationId %q is used in both %s and %s", op.OperationId, prev, origin)
+			}
+			m.opIDOrigins[op.OperationId] = origin
+		}
+		m.pathOrigins[method+" "+path] = origin
+	}
+
+	dst, ok := m.doc.Paths.PathItems.Get(path)
+	if !ok {
+		m.doc.Paths.PathItems.Set(path, item)
+		return nil
+	}
+	for _, field := range []struct{ dst, src **v3.Operation }{
+		{&dst.Get, &item.Get},
+		{&dst.Put, &item.Put},
+		{&dst.Post, &item.Post},
+		{&dst.Delete, &item.Delete},
+		{&dst.Options, &item.Options},
+		{&dst.Head, &item.Head},
+		{&dst.Patch, &item.Patch},
+		{&dst.Trace, &item.Trace},
+	} {
+		if *field.src != nil {
+			*field.dst = *field.src
+		}
+	}
+	dst.Parameters = append(dst.Parameters, item.Parameters...)
+	return nil
+}
+
+// renderer is implemented by the high-level model types.
+type renderer interface {
+	Render() ([]byte, error)
+}
+
+func recordNames[T any](origins map[string]string, kind string, names *orderedmap.Map[string, T], origin string) {
+	for name := range names.KeysFromOldest() {
+		origins[kind+" "+name] = origin
+	}
+}
+
+// mergeComponents adds the components in src of the given kind to *dst.
+// Components already present must render identically.
+func mergeComponents[T renderer](origins map[string]string, kind string, dst **orderedmap.Map[string, T], src *orderedmap.Map[string, T], origin string) error {
+	for name, c := range src.FromOldest() {
+		if *dst == nil {
+			*dst = orderedmap.New[string, T]()
+		}
+		key := kind + " " + name
+		prev, ok := (*dst).Get(name)
+		if !ok {
+			(*dst).Set(name, c)
+			origins[key] = origin
+			continue
+		}
+		a, errA := prev.Render()
+		b, errB := c.Render()
+		if errA != nil || errB != nil || !bytes.Equal(a, b) {
+			return fmt.Errorf("%s %q is defined differently in %s and %s", kind, name, origins[key], origin)
+		}
+	}
+	return nil
+}
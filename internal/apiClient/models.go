@@ -0,0 +1,498 @@
+package apiClient
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// modelData describes one generated model type. Struct models carry
+// Fields; enums carry Enum and unions Union; all others are defined as Type, or declared as
+// an alias of it.
+type modelData struct {
+	Name        string
+	Description string
+	// Example is the example of the schema as an indented JSON code block
+	// of its doc comment, if any.
+	Example    string
+	Deprecated bool
+	Struct     bool
+	Alias      bool
+	Fields     []fieldData
+	Type       string
+	// Enum lists the constants of an enum type, whose underlying type is
+	// Type. Lenient enums accept unknown values when unmarshaled.
+	Enum    []enumValue
+	Lenient bool
+	// Union describes a type holding one of several types.
+	Union *unionData
+	// Additional is the value type of the AdditionalProperties field of a
+	// struct, if any, which holds the properties other than the Known ones.
+	Additional string
+	Known      []string
+	// Constructor is the NewX function of a struct, if any.
+	Constructor *constructorData
+	// Validate is the Validate method of a struct, when Options.Validate
+	// is set.
+	Validate *validateData
+	// Error is the Error method of a struct that is the body of an error
+	// response.
+	Error *errorData
+	// XMLName is the name of the root element of a struct, from the xml
+	// object of its schema or else its name in the document, held by an
+	// XMLName field.
+	XMLName string
+	// Parts are the parts of a multipart request body, which its
+	// writeParts method writes.
+	Parts []partData
+
+	// schema is the inline schema a pending type is declared for.
+	schema *base.Schema
+}
+
+// fieldData describes one struct field of a model. Embedded fields have a
+// Type only. Omit is the option of the JSON tag leaving the field out when
+// encoded, such as "omitempty", if any, and Tags holds the struct tags
+// following the json one. Doc holds the description and example of the
+// property.
+type fieldData struct {
+	Name       string
+	Doc        string
+	Type       string
+	JSONName   string
+	Deprecated bool
+	Embedded   bool
+	Omit       string
+	Tags       string
+
+	// schema is the schema of the property, and optional reports whether
+	// it is not required, for buildValidation. raw marks a field of raw
+	// JSON, which has no default, constraint or tag derived from schema.
+	schema   *base.Schema
+	optional bool
+	raw      bool
+}
+
+// buildModels returns one model per schema in components.schemas, less
+// the deprecated ones nothing refers to when they are skipped.
+func (g *generator) buildModels() ([]modelData, error) {
+	if g.doc.Components == nil {
+		return nil, nil
+	}
+
+	var required map[string]bool
+	if g.skipDeprecated() {
+		required = g.requiredSchemas()
+	}
+
+	for _, name := range g.reservedNames() {
+		g.models[name] = true
+	}
+	// Schemas are named in document order, so that the first of those
+	// deriving the same name keeps it.
+	g.schemaTypes = map[string]string{}
+	for name, proxy := range g.doc.Components.Schemas.FromOldest() {
+		if required != nil && !required[name] {
+			continue
+		}
+		if goName := extension(proxy.Schema(), goNameExtension); goName != "" && !isExported(goName) {
+			g.log().Warn("invalid "+goNameExtension+", ignored", "schema", name, "value", goName)
+		}
+		wanted := g.schemaTypeName(name)
+		typeName := wanted
+		if typeName == "" {
+			typeName = "Schema"
+		}
+		typeName = freeName(typeName, g.models)
+		if typeName != wanted {
+			g.renamed("schema", name, wanted, typeName)
+		}
+		g.schemaTypes[name] = typeName
+		g.models[typeName] = true
+	}
+
+	var models []modelData
+	for name, proxy := range g.doc.Components.Schemas.FromOldest() {
+		if required != nil && !required[name] {
+			g.log().Debug("skipped deprecated schema", "schema", name)
+			continue
+		}
+		schema, err := proxy.BuildSchema()
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		m := g.schemaModel(name, g.schemaTypeName(name), name, proxy, schema)
+		g.log().Debug("generated model", "schema", name, "type", m.Name, "struct", m.Struct)
+		models = append(models, m)
+		models = append(models, g.pending...)
+		g.pending = nil
+	}
+	g.breakCycles(models)
+	g.structs = map[string][]fieldData{}
+	g.inlineTypes = map[*yaml.Node]string{}
+	for _, m := range models {
+		if m.Struct {
+			g.structs[m.Name] = m.Fields
+		}
+		if node := schemaNode(m.schema); node != nil {
+			g.inlineTypes[node] = m.Name
+		}
+	}
+	return models, nil
+}
+
+// schemaModel returns the model of the type name generated for schema: a
+// struct, possibly composed with allOf, an enum, a union or a type defined
+// as another, or an alias of a mapped type or of the type of its x-go-type
+// extension. owner names the schema in log messages, and root, if not
+// empty, is its name in the document, that of the XML root element of a
+// struct.
+func (g *generator) schemaModel(owner, name, root string, proxy *base.SchemaProxy, schema *base.Schema) modelData {
+	g.at = owner
+	typ, ok := g.extensionType(schema)
+	if g.rawJSON(owner, schema) {
+		typ, ok = rawJSONType, true
+	}
+	if ok {
+		return modelData{
+			Name:        name,
+			Description: schema.Description,
+			Example:     typeExample(schema),
+			Deprecated:  isDeprecated(schema.Deprecated),
+			Alias:       true,
+			Type:        typ,
+		}
+	}
+	if m, ok := g.allOfModel(owner, name, schema); ok {
+		return m
+	}
+	if isObject(schema) && schema.Properties != nil {
+		return g.objectModel(owner, name, root, schema)
+	}
+	if m, ok := g.enumModel(name, schema); ok {
+		return m
+	}
+	if m, ok := g.unionModel(owner, name, schema); ok {
+		return m
+	}
+	g.at, g.typeAt = owner, name+"Item"
+	_, mapped := g.typeMapping(schema)
+	return modelData{
+		Name:        name,
+		Description: schema.Description,
+		Example:     typeExample(schema),
+		Deprecated:  isDeprecated(schema.Deprecated),
+		// A defined type would lose the methods of a mapped one, such as
+		// the MarshalJSON of time.Time.
+		Alias: mapped,
+		Type:  g.goType(proxy),
+	}
+}
+
+// objectModel returns the struct model name for an object schema with
+// properties. owner names the schema in log messages, and root is the XML
+// root element of the struct if its xml object does not name one.
+func (g *generator) objectModel(owner, name, root string, schema *base.Schema) modelData {
+	m := modelData{
+		Name:        name,
+		Description: schema.Description,
+		Example:     typeExample(schema),
+		Deprecated:  isDeprecated(schema.Deprecated),
+		Struct:      true,
+		Fields:      g.structFields(owner, name, schema.Properties, schema.Required),
+	}
+	if g.xml {
+		m.XMLName = xmlRootName(schema, root)
+	}
+	g.additionalProperties(owner, &m, schema, schema.Properties)
+	m.Constructor = g.constructor(&m, schema.Properties, schema.Required)
+	return m
+}
+
+// inlineType declares a named type at g.typeAt for an inline enum, union,
+// allOf or object schema, to be emitted after the type that uses it, and
+// returns its name. It returns an empty name for other schemas. A taken name
+// is replaced by the first free one with a number appended, such as
+// PetOwner2. The inline schemas of models keep the types declared for them
+// wherever they are used again, such as in a struct leaving out read-only
+// properties.
+func (g *generator) inlineType(schema *base.Schema) string {
+	if name, ok := g.inlineTypes[schemaNode(schema)]; ok {
+		return g.qualifier + name
+	}
+	// Building the type moves g.at and g.typeAt to its members.
+	at, wanted := g.at, g.typeAt
+	if wanted == "" {
+		return ""
+	}
+	name := freeName(wanted, g.models)
+	if isObject(schema) && orderedmap.Len(schema.Properties) > 0 && len(schema.AllOf) == 0 {
+		g.claimType(at, wanted, name)
+		// Reserve the place of the struct ahead of the types of its fields.
+		i := len(g.pending)
+		g.pending = append(g.pending, modelData{})
+		g.pending[i] = g.objectModel(g.at, name, "", schema)
+		g.pending[i].schema = schema
+		return name
+	}
+	m, ok := g.enumModel(name, schema)
+	if !ok {
+		m, ok = g.unionModel(g.at, name, schema)
+	}
+	if !ok {
+		m, ok = g.allOfModel(g.at, name, schema)
+	}
+	if !ok {
+		return ""
+	}
+	g.claimType(at, wanted, name)
+	m.schema = schema
+	g.pending = append(g.pending, m)
+	return name
+}
+
+// claimType takes the name of an inline type declared at, which is a free
+// name derived from the wanted one.
+func (g *generator) claimType(at, wanted, name string) {
+	if name != wanted {
+		g.renamed("type", at, wanted, name)
+	}
+	g.models[name] = true
+}
+
+// schemaNode returns the node of the document schema is built from, which
+// is the same wherever the schema is reached, or nil if it has none.
+func schemaNode(schema *base.Schema) *yaml.Node {
+	if schema == nil || schema.GoLow() == nil {
+		return nil
+	}
+	return schema.GoLow().RootNode
+}
+
+// isPending reports whether the Go type typ, or the type it points to, is
+// a pending inline type.
+func (g *generator) isPending(typ string) bool {
+	name := strings.TrimPrefix(typ, "*")
+	for _, m := range g.pending {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// describePending sets the description of the pending type name, used for
+// types declared by goType on behalf of an operation.
+func (g *generator) describePending(name, description string) {
+	for i := range g.pending {
+		if g.pending[i].Name == name && g.pending[i].Description == "" {
+			g.pending[i].Description = description
+		}
+	}
+}
+
+// structFields returns the fields of the struct typeName generated for the
+// properties of an object schema, with the given required properties;
+// read-only and write-only ones are optional, as they are absent one way.
+// owner names the schema in log messages.
+func (g *generator) structFields(owner, typeName string, props *orderedmap.Map[string, *base.SchemaProxy], required []string) []fieldData {
+	required = directionalRequired(props, required)
+	var fields []fieldData
+	for propName, prop := range props.FromOldest() {
+		deprecated := isDeprecatedProperty(prop)
+		if deprecated && g.skipDeprecated() {
+			g.log().Debug("skipped deprecated property", "schema", owner, "property", propName)
+			continue
+		}
+		name := g.fieldName(owner, propName, prop)
+		g.at, g.typeAt = owner+"."+propName, typeName+name
+		f := fieldData{
+			Name:       name,
+			Type:       rawJSONType,
+			JSONName:   propName,
+			Deprecated: deprecated,
+			raw:        g.isRawProxy(prop),
+		}
+		if slices.Contains(g.opts.RawJSON, owner+"."+propName) {
+			f.raw = true
+		} else {
+			f.Type = g.goType(prop)
+		}
+		// The example of an inline type is given on the type.
+		f.Doc = fieldDoc(prop, !g.isPending(f.Type))
+		f.schema, f.optional = prop.Schema(), !slices.Contains(required, propName)
+		g.optionalField(&f, propName, prop, required)
+		f.Tags = g.structTags(f, prop, slices.Contains(required, propName) && !isNullable(prop.Schema()))
+		fields = append(fields, f)
+	}
+	g.uniqueFields(owner, fields)
+	return fields
+}
+
+// goType maps a schema to the Go type used to hold it.
+func (g *generator) goType(proxy *base.SchemaProxy) string {
+	if proxy == nil {
+		return "interface{}"
+	}
+	if proxy.IsReference() {
+		return g.qualifier + g.schemaTypeName(refName(proxy.GetReference()))
+	}
+
+	schema := proxy.Schema()
+	if schema == nil {
+		return "interface{}"
+	}
+	if isRaw(schema) {
+		return rawJSONType
+	}
+	if typ, ok := g.extensionType(schema); ok {
+		return typ
+	}
+	if typ, ok := g.mappedType(schema); ok {
+		return typ
+	}
+	for _, members := range [][]*base.SchemaProxy{schema.OneOf, schema.AnyOf} {
+		if members := nonNullMembers(members); len(members) == 1 {
+			// A nullable reference, in the style of OpenAPI 3.1.
+			return g.goType(members[0])
+		}
+	}
+	if len(schema.AllOf) == 1 && schema.AllOf[0].IsReference() && orderedmap.Len(schema.Properties) == 0 {
+		// A reference wrapped to add a description or the like.
+		return g.goType(schema.AllOf[0])
+	}
+	if name := g.inlineType(schema); name != "" {
+		return name
+	}
+	switch schemaType(schema) {
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items != nil && schema.Items.IsA() {
+			typeAt := g.typeAt
+			g.typeAt += "Item"
+			defer func() { g.typeAt = typeAt }()
+			return "[]" + g.goType(schema.Items.A)
+		}
+		g.degraded("[]interface{}", "array without items")
+		return "[]interface{}"
+	case "object":
+		if orderedmap.Len(schema.Properties) > 0 {
+			// Only where no type name can be derived.
+			g.degraded("map[string]interface{}", "inline object")
+		} else if typ, ok := g.additionalType(schema); ok {
+			return "map[string]" + typ
+		}
+		return "map[string]interface{}"
+	}
+	if len(schema.Type) > 0 {
+		g.degraded("interface{}", "unsupported type "+schemaType(schema))
+	}
+	return "interface{}"
+}
+
+// mappedType returns the Go type of typeMapping for schema and records the
+// import it needs.
+func (g *generator) mappedType(schema *base.Schema) (string, bool) {
+	target, ok := g.typeMapping(schema)
+	if !ok {
+		return "", false
+	}
+	switch target {
+	case dateType:
+		return g.dateType(), true
+	case decimalType:
+		if schemaType(schema) != "number" {
+			// Decimal is encoded as a JSON number.
+			g.degraded("string", decimalType+" only holds numbers")
+			return "string", true
+		}
+		return g.decimalType(), true
+	}
+	expr, importPath, _ := parseGoType(target)
+	if importPath != "" {
+		g.imports[importPath] = struct{}{}
+	}
+	return expr, true
+}
+
+// typeMapping looks up the type mapping for schema, first a user supplied
+// one by "type/format", then formatTypes and then a user supplied one by
+// "type".
+func (g *generator) typeMapping(schema *base.Schema) (string, bool) {
+	typ := schemaType(schema)
+	key := typ + "/" + schema.Format
+	if target, ok := g.opts.TypeMappings[key]; ok {
+		return target, true
+	}
+	if target, ok := formatTypes[key]; ok && schema.Format != "" {
+		return target, true
+	}
+	target, ok := g.opts.TypeMappings[typ]
+	return target, ok
+}
+
+// parseGoType splits a type mapping such as "github.com/google/uuid.UUID"
+// into the type expression "uuid.UUID" and the import path
+// "github.com/google/uuid". Unqualified types are returned unchanged.
+func parseGoType(s string) (expr, importPath string, err error) {
+	if s == "" {
+		return "", "", errors.New("empty Go type")
+	}
+	dot := strings.LastIndex(s, ".")
+	if dot < 0 {
+		if expr, err := parser.ParseExpr(s); err != nil {
+			return "", "", fmt.Errorf("invalid Go type %q", s)
+		} else if _, ok := expr.(*ast.BasicLit); ok {
+			return "", "", fmt.Errorf("invalid Go type %q", s)
+		}
+		return s, "", nil
+	}
+	importPath, name := s[:dot], s[dot+1:]
+	// Allow slice and pointer prefixes such as "[]time.Time".
+	trimmed := strings.TrimLeft(importPath, "[]*")
+	prefix := importPath[:len(importPath)-len(trimmed)]
+	importPath = trimmed
+	if importPath == "" || !isIdentifier(name) {
+		return "", "", fmt.Errorf("invalid Go type %q", s)
+	}
+	pkg := path.Base(importPath)
+	return prefix + pkg + "." + name, importPath, nil
+}
+
+// schemaType returns the primary (non-null) type of a schema.
+func schemaType(schema *base.Schema) string {
+	for _, t := range schema.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	if schema.Properties != nil {
+		return "object"
+	}
+	return ""
+}
+
+func isObject(schema *base.Schema) bool {
+	return schemaType(schema) == "object"
+}
+
+// refName returns the last path segment of a JSON reference such as
+// "#/components/schemas/Pet".
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
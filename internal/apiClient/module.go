@@ -0,0 +1,196 @@
+package apiClient
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+const (
+	goModFile   = "go.mod"
+	docFile     = "doc.go"
+	exampleFile = "examples/basic/main.go"
+	// goVersion is the go directive of a scaffolded go.mod, at least 1.24
+	// for the omitzero option of Optional fields.
+	goVersion = "1.24"
+)
+
+// moduleData is the value passed to the "gomod", "doc" and "example"
+// templates.
+type moduleData struct {
+	Header      string
+	ModulePath  string
+	GoVersion   string
+	ClientName  string
+	Title       string
+	Description string
+	Version     string
+	// ClientPackage is the name of the package holding ClientName, and
+	// ClientImport its import path.
+	ClientPackage string
+	ClientImport  string
+	// BaseURL is the base URL that the example passes to the constructor:
+	// empty, for the default server, or a placeholder if the document
+	// lists no servers.
+	BaseURL string
+	// Example is the operation called by the example program, if any, and
+	// ExamplePackage the package it belongs to.
+	Example        *operationData
+	ExamplePackage string
+	ExampleImport  string
+}
+
+// modulePathFor returns the module path for the document: opts.Module with
+// a /vN suffix appended when info.version has a major version of 2 or more,
+// as Go requires for such modules.
+func modulePathFor(module string, doc *v3.Document) string {
+	if doc.Info == nil {
+		return module
+	}
+	major := majorVersion(doc.Info.Version)
+	if major < 2 {
+		return module
+	}
+	if _, last := path.Split(module); last == "v"+strconv.Itoa(major) {
+		return module
+	}
+	return module + "/v" + strconv.Itoa(major)
+}
+
+// majorVersion returns the major version of a "1.2.3" or "v1.2.3" style
+// version, or 0 if it does not look like one.
+func majorVersion(version string) int {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	n, err := strconv.Atoi(major)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// generateModule renders the client into a package directory of a new
+// module rooted at opts.OutPath, along with a go.mod, package
+// documentation and an example program. An existing go.mod is left alone,
+// so that requirements added with go mod tidy survive regeneration.
+func generateModule(spec *Spec, opts Options) ([]File, error) {
+	doc := spec.Document
+	root := opts.OutPath
+	modPath := modulePathFor(opts.Module, doc)
+	pkgDir := opts.PackageName
+
+	clientOpts := opts
+	clientOpts.OutPath = filepath.Join(root, pkgDir)
+	if clientOpts.Layout == "" || clientOpts.Layout == LayoutSingle {
+		clientOpts.OutPath = filepath.Join(clientOpts.OutPath, clientFile)
+	}
+	clientOpts.ImportPath = path.Join(modPath, pkgDir)
+
+	tmpl, err := loadTemplates(opts.TemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+	prov := newProvenance(spec)
+	header, err := loadHeader(opts.HeaderFile, prov)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{opts: clientOpts, doc: doc, imports: map[string]struct{}{}, models: map[string]bool{}, methods: map[string]bool{}, xml: documentUsesXML(doc), codecs: documentUsesCodecs(doc), dir: pkgDir, header: header, provenance: prov}
+	if !opts.NoCache {
+		g.cache = openCache(root, opts.TemplatesDir)
+	}
+	models, err := g.buildModels()
+	if err != nil {
+		return nil, err
+	}
+	operations, err := g.buildOperations()
+	if err != nil {
+		return nil, err
+	}
+	g.buildValidation(models, operations)
+	g.buildErrors(models, operations)
+	files, err := g.render(tmpl, models, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	data := moduleData{
+		Header:        header,
+		ModulePath:    modPath,
+		GoVersion:     goVersion,
+		ClientName:    opts.ClientName,
+		ClientPackage: opts.PackageName,
+		ClientImport:  clientOpts.ImportPath,
+	}
+	if g.defaultServer() == nil {
+		data.BaseURL = "https://api.example.com"
+	}
+	if doc.Info != nil {
+		data.Title = doc.Info.Title
+		data.Description = doc.Info.Description
+		data.Version = doc.Info.Version
+	}
+	docDir := pkgDir
+	if opts.Layout == LayoutPackages {
+		data.ClientPackage = corePackage
+		data.ClientImport = path.Join(clientOpts.ImportPath, corePackage)
+		docDir = pkgDir + "/" + corePackage
+	}
+	for _, op := range operations {
+		if op.RequestType != "" || op.Params != "" {
+			continue
+		}
+		data.Example = &op
+		data.ExamplePackage = data.ClientPackage
+		data.ExampleImport = data.ClientImport
+		if opts.Layout == LayoutPackages {
+			if pkg := tagPackageName(op.Tag); pkg != corePackage {
+				data.ExamplePackage = pkg
+				data.ExampleImport = path.Join(clientOpts.ImportPath, pkg)
+			}
+		}
+		break
+	}
+
+	if _, err := os.Stat(filepath.Join(root, goModFile)); os.IsNotExist(err) {
+		var b bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&b, "gomod", data); err != nil {
+			return nil, fmt.Errorf("executing templates for %s: %w", goModFile, err)
+		}
+		files = append(files, File{Name: goModFile, Content: b.Bytes()})
+	}
+	for _, f := range []struct {
+		name, template string
+		imports        []string
+	}{
+		{docDir + "/" + docFile, "doc", nil},
+		{exampleFile, "example", []string{data.ClientImport, data.ExampleImport}},
+	} {
+		code, err := renderGo(tmpl, f.name, f.template, data, f.imports)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		files = append(files, File{Name: f.name, Content: code})
+	}
+	return files, nil
+}
+
+// renderGo executes the template define for the file name and formats the
+// result as Go source.
+func renderGo(tmpl *template.Template, name, define string, data any, imports []string) ([]byte, error) {
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&b, define, data); err != nil {
+		return nil, fmt.Errorf("executing templates: %w", err)
+	}
+	code, err := runPostPasses(name, b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return formatSource(code, imports)
+}
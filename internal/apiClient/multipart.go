@@ -0,0 +1,169 @@
+package apiClient
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+const multipartMediaType = "multipart/form-data"
+
+// partData describes how a field of a multipart request body is written:
+// as the part Name, under the Content-Disposition header Disposition, when
+// Guard holds. Kind is "file" or "files" for an io.Reader or a slice of
+// them, copied into file parts, "field" or "fields" for a value or the
+// elements e of a slice, formatted by Elem into form fields, or "json" for
+// other values, encoded into a JSON part. Value is the expression of the
+// field, and Elem that of the reader of a file.
+type partData struct {
+	Name        string
+	Disposition string
+	Guard       string
+	Value       string
+	Kind        string
+	Elem        string
+}
+
+// multipartContent returns the multipart/form-data media type of content.
+func multipartContent(content *orderedmap.Map[string, *v3.MediaType]) (*v3.MediaType, bool) {
+	return mediaTypeContent(content, multipartMediaType)
+}
+
+// mediaTypeContent returns the media type of content named mediaType,
+// whatever its parameters.
+func mediaTypeContent(content *orderedmap.Map[string, *v3.MediaType], mediaType string) (*v3.MediaType, bool) {
+	for name, mt := range content.FromOldest() {
+		if name, _, _ := strings.Cut(name, ";"); strings.TrimSpace(name) == mediaType {
+			return mt, true
+		}
+	}
+	return nil, false
+}
+
+// isFile reports whether prop holds the raw bytes of a file, a string of
+// the binary format.
+func isFile(prop *base.SchemaProxy) bool {
+	schema := prop.Schema()
+	return schema != nil && schemaType(schema) == "string" && schema.Format == binaryFormat
+}
+
+// multipartType declares on data the struct name of the multipart request
+// body mt, with an io.Reader field per file and a typed field per other
+// property, and returns it, or false if mt is not an object. Fields are
+// sent as they are written, without buffering the body; optional ones are
+// pointers unless their type has a nil value of its own.
+func (g *generator) multipartType(data *operationData, name string, mt *v3.MediaType) (string, bool) {
+	var schema *base.Schema
+	if mt.Schema != nil {
+		schema = mt.Schema.Schema()
+	}
+	if schema == nil || !isObject(schema) {
+		return "", false
+	}
+	if free := freeName(name, g.models); free != name {
+		g.renamed("type", data.Name+" request body", name, free)
+		name = free
+		g.at, g.typeAt = name, name
+	}
+	props, required, _ := withoutProperties(schema, isReadOnly)
+	m := modelData{
+		Name:        name,
+		Description: "is the " + multipartMediaType + " request body of " + data.Name + ".",
+		Struct:      true,
+	}
+	for propName, prop := range props.FromOldest() {
+		f := fieldData{
+			Name:       g.fieldName(name, propName, prop),
+			JSONName:   propName,
+			Deprecated: isDeprecatedProperty(prop),
+			Doc:        fieldDoc(prop, false),
+		}
+		g.at, g.typeAt = name+"."+propName, name+f.Name
+		switch schema := prop.Schema(); {
+		case isFile(prop):
+			f.Type = "io.Reader"
+		case schema != nil && schemaType(schema) == "array" && schema.Items != nil && schema.Items.IsA() && isFile(schema.Items.A):
+			f.Type = "[]io.Reader"
+		default:
+			f.Type = g.goType(prop)
+			f.schema, f.optional = schema, !slices.Contains(required, propName)
+			if f.optional && !isNilable(f.Type) {
+				f.Type = "*" + f.Type
+			}
+		}
+		m.Fields = append(m.Fields, f)
+	}
+	g.uniqueFields(name, m.Fields)
+	for _, f := range m.Fields {
+		m.Parts = append(m.Parts, g.part(f, g.qualifier))
+	}
+	g.models[name] = true
+	data.Types = append(data.Types, m)
+	data.Multipart = true
+	return name, true
+}
+
+// part returns how the field f of a multipart or form request body is
+// written, as a part or a form value. Types in f are prefixed with
+// qualifier.
+func (g *generator) part(f fieldData, qualifier string) partData {
+	p := partData{
+		Name:        f.JSONName,
+		Disposition: `form-data; name="` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(f.JSONName) + `"`,
+		Value:       "v." + f.Name,
+	}
+	switch {
+	case f.Type == "io.Reader":
+		p.Kind, p.Guard, p.Elem = "file", p.Value+" != nil", p.Value
+		return p
+	case f.Type == "[]io.Reader":
+		p.Kind, p.Elem = "files", "e"
+		return p
+	}
+	c, typ, ok := fieldValue(f, qualifier)
+	if !ok {
+		// A field of x-go-type or raw JSON.
+		p.Kind = "json"
+		return p
+	}
+	if c.Guard == "" && f.optional {
+		c.Guard = zeroGuard(f)
+	}
+	p.Guard, p.Value = c.Guard, c.Value
+	if p.Guard == "" && isNilable(typ) {
+		p.Guard = p.Value + " != nil"
+	}
+	schema := constraintSchema(f.schema)
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok && schema != nil && schema.Items != nil && schema.Items.IsA() {
+		schema = constraintSchema(schema.Items.A.Schema())
+		typ = elem
+		p.Kind = "fields"
+	}
+	if value, ok := paramValue(p.Value, typ); ok && isScalar(schema) {
+		if p.Kind == "fields" {
+			value, _ = paramValue("e", typ)
+		} else {
+			p.Kind = "field"
+		}
+		p.Elem = value
+		return p
+	}
+	p.Kind = "json"
+	return p
+}
+
+// isScalar reports whether schema is a string, number or boolean, sent as
+// the text of a form field.
+func isScalar(schema *base.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	switch schemaType(schema) {
+	case "string", "integer", "number", "boolean":
+		return true
+	}
+	return false
+}
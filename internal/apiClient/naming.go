@@ -0,0 +1,257 @@
+package apiClient
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Naming controls how generated identifiers are derived.
+type Naming struct {
+	// MethodNames selects the source of method names: "operationId" (the
+	// default) uses the operationId when present and falls back to the path,
+	// "path" always derives the name from the HTTP method and path.
+	MethodNames string `json:"methodNames" yaml:"methodNames"`
+	// TypePrefix is prepended to every generated model type name.
+	TypePrefix string `json:"typePrefix" yaml:"typePrefix"`
+	// Rename maps component schema names, and properties as
+	// "Schema.property", to the Go identifiers generated for them. Like the
+	// x-go-name extension, which takes precedence, it bypasses TypePrefix.
+	Rename map[string]string `json:"rename" yaml:"rename"`
+	// Initialisms are the words written in upper case in identifiers, such
+	// as ID in PetID. Nil selects DefaultInitialisms; an empty list keeps
+	// every word capitalized only.
+	Initialisms []string `json:"initialisms" yaml:"initialisms"`
+}
+
+// defaultInitialisms are those golint checks for.
+var defaultInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP",
+	"HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA",
+	"SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID",
+	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// DefaultInitialisms returns the initialisms of Naming by default, to be
+// extended with others.
+func DefaultInitialisms() []string {
+	return slices.Clone(defaultInitialisms)
+}
+
+// initialismSet returns the upper-cased initialisms of n.
+func (n Naming) initialismSet() map[string]bool {
+	list := n.Initialisms
+	if list == nil {
+		list = defaultInitialisms
+	}
+	set := make(map[string]bool, len(list))
+	for _, word := range list {
+		set[strings.ToUpper(word)] = true
+	}
+	return set
+}
+
+func (n Naming) validate() error {
+	switch n.MethodNames {
+	case "", "operationId", "path":
+	default:
+		return fmt.Errorf("invalid naming.methodNames %q: want operationId or path", n.MethodNames)
+	}
+	if n.TypePrefix != "" && !isIdentifier(toGoName(n.TypePrefix)) {
+		return fmt.Errorf("invalid naming.typePrefix %q", n.TypePrefix)
+	}
+	for from, to := range n.Rename {
+		if !isExported(to) {
+			return fmt.Errorf("invalid naming.rename of %q: %q is not an exported identifier", from, to)
+		}
+	}
+	for _, word := range n.Initialisms {
+		if !isIdentifier(word) || strings.ContainsRune(word, '_') {
+			return fmt.Errorf("invalid naming.initialisms entry %q: want letters and digits", word)
+		}
+	}
+	return nil
+}
+
+// operationName returns the Go method name for an operation, preferring its
+// operationId over a name derived from the method and path.
+func (g *generator) operationName(method, path string, op *v3.Operation) string {
+	if op.OperationId != "" && g.opts.Naming.MethodNames != "path" {
+		return g.goName(op.OperationId)
+	}
+	return g.pathToFuncName(method, path)
+}
+
+// typeName returns the Go type name derived from a schema or operation
+// name.
+func (g *generator) typeName(schemaName string) string {
+	return g.goName(g.opts.Naming.TypePrefix) + g.goName(schemaName)
+}
+
+// goName converts a spec name into an exported Go identifier like toGoName,
+// writing the initialisms of Naming in upper case.
+func (g *generator) goName(name string) string {
+	if g.initialisms == nil {
+		g.initialisms = g.opts.Naming.initialismSet()
+	}
+	return goName(name, g.initialisms)
+}
+
+// goNameExtension overrides the Go identifier generated for a schema or a
+// property.
+const goNameExtension = "x-go-name"
+
+// schemaTypeName returns the Go type name for the component schema
+// schemaName: its x-go-name, its entry in Naming.Rename, or else the name
+// derived by typeName, unless buildModels had to rename it.
+func (g *generator) schemaTypeName(schemaName string) string {
+	if name, ok := g.schemaTypes[schemaName]; ok {
+		return name
+	}
+	if c := g.doc.Components; c != nil && c.Schemas != nil {
+		if proxy, ok := c.Schemas.Get(schemaName); ok {
+			if name := extension(proxy.Schema(), goNameExtension); isExported(name) {
+				return name
+			}
+		}
+	}
+	if name, ok := g.opts.Naming.Rename[schemaName]; ok {
+		return name
+	}
+	return g.typeName(schemaName)
+}
+
+// fieldName returns the Go field name for the property propName of the
+// schema owner: the x-go-name of an inline property, its entry in
+// Naming.Rename as "owner.propName", or else the name derived by toGoName.
+func (g *generator) fieldName(owner, propName string, prop *base.SchemaProxy) string {
+	if !prop.IsReference() {
+		if name := extension(prop.Schema(), goNameExtension); isExported(name) {
+			return name
+		} else if name != "" {
+			g.log().Warn("invalid "+goNameExtension+", ignored", "schema", owner, "property", propName, "value", name)
+		}
+	}
+	if name, ok := g.opts.Naming.Rename[owner+"."+propName]; ok {
+		return name
+	}
+	return g.goName(propName)
+}
+
+// pathToFuncName derives a method name such as GetPetsByPetID from
+// "GET /pets/{petId}".
+func (g *generator) pathToFuncName(method, path string) string {
+	var b strings.Builder
+	b.WriteString(g.goName(strings.ToLower(method)))
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			b.WriteString("By")
+			seg = strings.Trim(seg, "{}")
+		}
+		b.WriteString(g.goName(seg))
+	}
+	return b.String()
+}
+
+// toGoName converts an arbitrary spec name into an exported Go identifier by
+// splitting on non-alphanumeric characters and capitalising each word.
+func toGoName(name string) string {
+	return goName(name, nil)
+}
+
+// goName is toGoName writing the words of camel case names, such as Id in
+// petId, in upper case when they are in initialisms, and their plurals,
+// such as ids, as IDs.
+func goName(name string, initialisms map[string]bool) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, w := range words {
+		for _, part := range camelWords(w) {
+			if upper := strings.ToUpper(part); initialisms[upper] {
+				b.WriteString(upper)
+				continue
+			}
+			if stem, ok := strings.CutSuffix(part, "s"); ok && initialisms[strings.ToUpper(stem)] {
+				b.WriteString(strings.ToUpper(stem) + "s")
+				continue
+			}
+			r := []rune(part)
+			r[0] = unicode.ToUpper(r[0])
+			b.WriteString(string(r))
+		}
+	}
+
+	out := b.String()
+	if out != "" && unicode.IsDigit([]rune(out)[0]) {
+		out = "N" + out
+	}
+	return out
+}
+
+// camelWords splits a camel case word such as "userID" or "HTMLBody" into
+// its parts, "user" and "ID" or "HTML" and "Body". Digits stay with the
+// part they follow.
+func camelWords(w string) []string {
+	runes := []rune(w)
+	var parts []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, r := runes[i-1], runes[i]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(r) && (!unicode.IsUpper(prev) || nextLower) {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
+
+// unexportedName returns the unexported form of the identifier s, lowering
+// a leading initialism as a whole: id for ID, ids for IDs, apiKey for
+// APIKey.
+func unexportedName(s string) string {
+	r := []rune(s)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	switch {
+	case n > 1 && n < len(r) && r[n] == 's' && (n+1 == len(r) || !unicode.IsLower(r[n+1])):
+		// The plural of an initialism.
+	case n > 1 && n < len(r) && unicode.IsLower(r[n]):
+		// The last upper case letter starts the next word.
+		n--
+	}
+	for i := 0; i < n; i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func isIdentifier(s string) bool {
+	return token.IsIdentifier(s)
+}
+
+func isExported(s string) bool {
+	return token.IsIdentifier(s) && token.IsExported(s)
+}
@@ -0,0 +1,91 @@
+package apiClient
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGoName checks that initialisms and their plurals are written in upper
+// case in the names derived from the document, and lowered as a whole in
+// unexported ones.
+func TestGoName(t *testing.T) {
+	initialisms := Naming{}.initialismSet()
+	tests := []struct {
+		name, exported, unexported string
+	}{
+		{"id", "ID", "id"},
+		{"ids", "IDs", "ids"},
+		{"petIds", "PetIDs", "petIDs"},
+		{"pet_ids", "PetIDs", "petIDs"},
+		{"idsByName", "IDsByName", "idsByName"},
+		{"urls", "URLs", "urls"},
+		{"api_key", "APIKey", "apiKey"},
+		{"https", "HTTPS", "https"},
+		{"status", "Status", "status"},
+		{"is", "Is", "is"},
+	}
+	for _, tt := range tests {
+		got := goName(tt.name, initialisms)
+		if got != tt.exported {
+			t.Errorf("goName(%q) = %q, want %q", tt.name, got, tt.exported)
+		}
+		if got := unexportedName(got); got != tt.unexported {
+			t.Errorf("unexportedName(%q) = %q, want %q", tt.exported, got, tt.unexported)
+		}
+	}
+}
+
+// TestPluralParamName checks that the field of a parameter named after the
+// plural of an initialism is written with the initialism.
+func TestPluralParamName(t *testing.T) {
+	src := string(generateFile(t, `
+openapi: 3.0.3
+info: {title: plural, version: "1"}
+paths:
+  /pets:
+    put:
+      operationId: putPets
+      parameters:
+        - {name: ids, in: query, schema: {type: array, items: {type: integer}}}
+      responses:
+        "204": {description: ok}
+`))
+	if !strings.Contains(src, "IDs []int") {
+		t.Errorf("no IDs field in PutPetsParams:\n%s", src)
+	}
+}
+
+// TestInlineUnionNames checks that the inline members of unions, which are
+// named after the property holding them, are not reported as renamed when no
+// name collides.
+func TestInlineUnionNames(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info: {title: unions, version: "1"}
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        one:
+          oneOf:
+            - {type: string}
+            - type: object
+              properties:
+                id: {type: string}
+        any:
+          anyOf:
+            - {type: integer}
+            - type: array
+              items:
+                type: object
+                properties:
+                  id: {type: string}
+`
+	report := &Report{}
+	generateFile(t, spec, func(o *Options) { o.Report = report })
+	if len(report.Renames) != 0 {
+		t.Errorf("renames = %+v, want none", report.Renames)
+	}
+}
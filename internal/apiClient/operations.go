@@ -0,0 +1,317 @@
+package apiClient
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// operationData describes one generated client method.
+type operationData struct {
+	// Receiver is the name of the client type the method is defined on.
+	Receiver string
+	Name     string
+	Method   string
+	Path     string
+	Summary  string
+	// Description is the description of the operation, following its
+	// summary in the doc comment of the method.
+	Description string
+	// Tag is the first tag of the operation, used to pick its file in the
+	// split layout.
+	Tag        string
+	Deprecated bool
+	// PathExpr is the Go expression of the path, into which the path
+	// parameters are substituted.
+	PathExpr string
+	// RequestType is the Go type of the request body, empty when the
+	// operation takes none. StreamContentType is the media type of a binary
+	// body, which is an io.Reader streamed as it is rather than JSON, and
+	// XMLContentType that of an XML body, encoded with encoding/xml by the
+	// method itself, or by the method named XMLBody if the body may be JSON
+	// or a form as well, and CodecContentType that of a body encoded by the
+	// Codec of the client.
+	// BodyMediaType is the Content-Type of a JSON body, application/json
+	// unless the document names another, such as
+	// application/merge-patch+json.
+	// Multipart marks a multipart/form-data body, written by the
+	// writeParts method of the request type as it is sent. WithBody is the
+	// name of the method taking the body as an io.Reader of any content
+	// type, which the method sends it with.
+	RequestType       string
+	StreamContentType string
+	XMLContentType    string
+	XMLBody           string
+	CodecContentType  string
+	BodyMediaType     string
+	Multipart         bool
+	WithBody          string
+	// FormParts encode the request body as a form, when it may be one: by
+	// the method itself if Form is set, or else by the method named
+	// FormBody, as the body may be JSON as well.
+	Form      bool
+	FormBody  string
+	FormParts []partData
+	// Params is the name of the struct of the parameters, such as
+	// GetPetParams, which the method takes; empty when there are none.
+	Params     string
+	PathParams []paramField
+	Query      []paramField
+	Headers    []paramField
+	Cookies    []paramField
+	// Builders are the methods of the Params struct setting the query
+	// parameters of the filter, sort and fields conventions.
+	Builders []builderData
+	// Option and ResponseError are the possibly qualified names of the
+	// RequestOption and ResponseError types.
+	// Defaults are the options the method applies before those of the
+	// call: Idempotent, for an operation declaring the Idempotency-Key
+	// header, WithServerURL, for one with servers of its own, WithTimeout,
+	// for one with a timeout, and WithSecurity, for one with security
+	// requirements of its own.
+	Option        string
+	ResponseError string
+	Defaults      []string
+	// Accept is the Accept header of the request, listing the media types
+	// of the responses that are decoded, see acceptedMediaTypes.
+	Accept string
+	// Response is the name of the result type, which holds the raw
+	// response and a field per entry of Responses.
+	Response  string
+	Responses []responseData
+	// HasDefault reports whether the operation documents a default
+	// response, so that no status is unexpected.
+	HasDefault bool
+	// Download is the name of the method writing the binary bodies of the
+	// responses marked Download to an io.Writer, such as GetPhotoTo; empty
+	// when the operation has none. Streaming marks the data of that method.
+	Download  string
+	Streaming bool
+	// ToFile is the name of the method saving those bodies to a file, such
+	// as GetPhotoToFile, and Saving marks its data. FilenameFunc and
+	// SaveFunc are the possibly qualified names of the SuggestedFilename
+	// and SaveFile functions it uses; all are empty when these names are
+	// taken.
+	ToFile       string
+	Saving       bool
+	FilenameFunc string
+	SaveFunc     string
+	// Range is the name of the method writing a range of the bytes of
+	// those bodies, such as GetPhotoRange, and Ranging marks its data.
+	// ContentRangeFunc and WriteRangeFunc are the possibly qualified names
+	// of the ContentRange and WriteRange functions it uses.
+	Range            string
+	Ranging          bool
+	ContentRangeFunc string
+	WriteRangeFunc   string
+	// Events is the name of the method yielding the server-sent events of
+	// a text/event-stream response, such as WatchPetsEvents, empty when
+	// there is none. EventType and EventFunc are the possibly qualified
+	// names of the ServerSentEvent type and the ServerSentEvents function,
+	// and EventData the type of the data of the events.
+	Events    string
+	EventType string
+	EventFunc string
+	EventData string
+	// Lines is the name of the method yielding the values of an NDJSON or
+	// JSON Lines response, such as ExportPetsStream, empty when there is
+	// none. LinesFunc is the possibly qualified name of the JSONLines
+	// function and LineType the type of the values.
+	Lines     string
+	LinesFunc string
+	LineType  string
+	// Items is the name of the method yielding the items of a JSON array
+	// response, such as ListPetsItems, when Options.StreamArrays is set,
+	// and ItemType their type. ItemsFunc is the possibly qualified name of
+	// the JSONArray function and ItemsMediaType the media type to accept.
+	Items          string
+	ItemsFunc      string
+	ItemType       string
+	ItemsMediaType string
+	// ResponseHeaders are the headers the responses declare, held by
+	// fields of the result type.
+	ResponseHeaders []responseHeaderData
+	// Rows is the name of the method yielding the rows of a text/csv
+	// response, such as ExportPetsRows, empty when there is none, and
+	// RowType their type. CSVFunc and RowsFunc are the possibly qualified
+	// names of the ReadCSV and CSVRows functions.
+	Rows     string
+	RowType  string
+	CSVFunc  string
+	RowsFunc string
+	// Poller is the name of the method returning a Poller of the
+	// long-running operation started by a 202 Accepted response, such as
+	// CreateReportPoller, empty when there is none. PollerType and
+	// NewPollerFunc are the possibly qualified names of the Poller type
+	// and the NewPoller function.
+	Poller        string
+	PollerType    string
+	NewPollerFunc string
+	// Follow, Next and Prev are the names of the methods following the
+	// links of a Link header of the result, such as ListPetsFollow, empty
+	// when no response declares one, and Following marks the data of
+	// Follow. LinksType, ParseLinksFunc and ErrNoLink are the possibly
+	// qualified names of the WebLinks type, the ParseLinks function and
+	// the ErrNoLink error.
+	Follow         string
+	Next           string
+	Prev           string
+	Following      bool
+	LinksType      string
+	ParseLinksFunc string
+	ErrNoLink      string
+	// Types are the request and response body types declared for the
+	// operation, next to its method.
+	Types []modelData
+	// ValidateRequest calls the Validate method of the request body before
+	// sending it, and ValidateParams that of the Params struct, whose
+	// Validate method ParamsModel holds, if its parameters have
+	// constraints.
+	ValidateRequest bool
+	ValidateParams  bool
+	ParamsModel     *modelData
+}
+
+// buildOperations collects the operations of every path that pass the
+// configured filter and deprecation policy, in document order.
+func (g *generator) buildOperations() ([]operationData, error) {
+	if g.doc.Paths == nil {
+		return nil, nil
+	}
+	g.methods["Do"] = true
+	if g.opts.Layout == LayoutPackages {
+		// The methods of the core client used by the per-tag packages.
+		g.methods["Send"] = true
+		g.methods["Stream"] = true
+		g.methods["EncodeJSON"] = true
+		g.methods["DecodeJSON"] = true
+		g.methods["Codec"] = g.codecs
+		g.methods["ValidateResponse"] = true
+	}
+
+	var ops []operationData
+	for path, item := range g.doc.Paths.PathItems.FromOldest() {
+		for method, op := range pathOperations(item) {
+			if !g.opts.Filter.includes(method, path, op) {
+				g.skipped(method, path, op.OperationId, "filtered")
+				continue
+			}
+			if isDeprecated(op.Deprecated) && g.skipDeprecated() {
+				g.skipped(method, path, op.OperationId, "deprecated")
+				continue
+			}
+			g.qualifier = ""
+			if g.opts.Layout == LayoutPackages && tagPackageName(firstTag(op)) != corePackage {
+				g.qualifier = corePackage + "."
+			}
+			data, degradations, err := g.buildOperation(method, path, item, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			g.generated(&data, op.OperationId, degradations)
+			ops = append(ops, data)
+		}
+	}
+	g.qualifier = ""
+	return ops, nil
+}
+
+// buildOperation returns the method generated for op, and what in it could
+// not be typed faithfully.
+func (g *generator) buildOperation(method, path string, item *v3.PathItem, op *v3.Operation) (operationData, []string, error) {
+	name := g.operationName(method, path, op)
+	if name == "" {
+		return operationData{}, nil, errors.New("cannot derive a function name")
+	}
+	if free := freeName(name, g.methods); free != name {
+		g.renamed("operation", method+" "+path, name, free)
+		name = free
+	}
+	g.methods[name] = true
+	data := operationData{
+		Receiver:      g.opts.ClientName,
+		Name:          name,
+		Method:        method,
+		Path:          path,
+		Summary:       op.Summary,
+		Tag:           firstTag(op),
+		Option:        g.qualifier + requestOption,
+		ResponseError: g.qualifier + responseError,
+
+		Description: op.Description,
+		Deprecated:  isDeprecated(op.Deprecated),
+	}
+	g.degradations, g.pending = nil, nil
+	params := operationParameters(item, op)
+	if err := g.buildParams(&data, path, params); err != nil {
+		return operationData{}, nil, err
+	}
+	g.buildQueryBuilders(&data)
+	if err := g.buildBodies(&data, op); err != nil {
+		return operationData{}, nil, err
+	}
+	g.buildDownload(&data)
+	g.buildLinks(&data, op)
+	g.buildResponseHeaders(&data, op)
+	g.buildEvents(&data, op)
+	g.buildLines(&data, op)
+	g.buildItems(&data, op)
+	g.buildRows(&data, op)
+	g.buildPoller(&data, op)
+	if option := g.serverOption(item, op); option != "" {
+		data.Defaults = append(data.Defaults, option)
+	}
+	if option := g.timeoutOption(op); option != "" {
+		data.Defaults = append(data.Defaults, option)
+	}
+	if option := g.securityOption(op); option != "" {
+		data.Defaults = append(data.Defaults, option)
+	}
+	data.Types = append(data.Types, g.pending...)
+	g.pending = nil
+	if data.Deprecated {
+		// The types of the operation go with it.
+		for i := range data.Types {
+			data.Types[i].Deprecated = true
+		}
+	}
+	return data, g.degradations, nil
+}
+
+// pathOperations yields every operation of a path item, keyed by HTTP
+// method.
+func pathOperations(item *v3.PathItem) iter.Seq2[string, *v3.Operation] {
+	return func(yield func(string, *v3.Operation) bool) {
+		ops := []struct {
+			method string
+			op     *v3.Operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodDelete, item.Delete},
+			{http.MethodPatch, item.Patch},
+			{http.MethodHead, item.Head},
+			{http.MethodOptions, item.Options},
+			{http.MethodTrace, item.Trace},
+		}
+		for _, o := range ops {
+			if o.op == nil {
+				continue
+			}
+			if !yield(o.method, o.op) {
+				return
+			}
+		}
+	}
+}
+
+func firstTag(op *v3.Operation) string {
+	if len(op.Tags) == 0 {
+		return ""
+	}
+	return op.Tags[0]
+}
@@ -0,0 +1,143 @@
+package apiClient
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// OptionalStrategy selects how struct fields that are optional, because
+// their property is not required, or nullable are represented.
+type OptionalStrategy string
+
+const (
+	// OptionalValue uses the plain type, so that an absent or null
+	// property cannot be told from its zero value.
+	OptionalValue OptionalStrategy = "value"
+	// OptionalPointer uses a pointer, unless the type is a slice, map or
+	// interface already, whose nil value stands for absent and null.
+	OptionalPointer OptionalStrategy = "pointer"
+	// OptionalGeneric uses the generated generic type Optional[T], which
+	// tells an absent property from a null one and from its zero value.
+	OptionalGeneric OptionalStrategy = "optional"
+	// OptionalSQL uses the generated generic type Null[T], which embeds
+	// sql.Null[T] and so can be scanned from and stored in a database.
+	// Slices, maps and interfaces are left as they are.
+	OptionalSQL OptionalStrategy = "sql"
+)
+
+func (s OptionalStrategy) validate() error {
+	switch s {
+	case "", OptionalValue, OptionalPointer, OptionalGeneric, OptionalSQL:
+		return nil
+	}
+	return fmt.Errorf("invalid optional strategy %q: want %s, %s, %s or %s", s, OptionalValue, OptionalPointer, OptionalGeneric, OptionalSQL)
+}
+
+// helperTypes returns the names of the types and functions generated
+// alongside the client for the strategy, which schemas must not take.
+func (s OptionalStrategy) helperTypes() []string {
+	switch s {
+	case OptionalGeneric:
+		return []string{"Optional", "Some"}
+	case OptionalSQL:
+		return []string{"Null", "NullValue"}
+	}
+	return nil
+}
+
+// optionalField sets the type and the omit option of f, holding the
+// property named propName of an object with the given required properties,
+// according to the optional strategy. Optional fields are left out when
+// encoded if empty, or if absent for the generic types; with plain values,
+// structs and mapped named types are pointers for that, since they are
+// never empty.
+func (g *generator) optionalField(f *fieldData, propName string, prop *base.SchemaProxy, required []string) {
+	schema := prop.Schema()
+	optional := !slices.Contains(required, propName)
+	if !optional && !isNullable(schema) {
+		return
+	}
+	// Raw JSON is a slice, even behind the name of a schema.
+	nilable := isNilable(f.Type) || f.raw
+	wrapped := false
+	switch g.opts.Optional {
+	case OptionalPointer:
+		if !nilable {
+			f.Type = "*" + f.Type
+		}
+	case OptionalGeneric:
+		f.Type = g.qualifier + "Optional[" + f.Type + "]"
+		wrapped = true
+	case OptionalSQL:
+		if !nilable {
+			f.Type = g.qualifier + "Null[" + f.Type + "]"
+			wrapped = true
+		}
+	default:
+		if optional && !nilable && (isStructType(schema) || g.mapsToNamedType(schema)) {
+			f.Type = "*" + f.Type
+		}
+	}
+	switch {
+	case !optional:
+	case wrapped:
+		f.Omit = "omitzero"
+	default:
+		f.Omit = "omitempty"
+	}
+}
+
+// isStructType reports whether schema is generated as a struct type, a
+// plain struct or a union.
+func isStructType(schema *base.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	for _, members := range [][]*base.SchemaProxy{schema.OneOf, schema.AnyOf} {
+		switch members := nonNullMembers(members); len(members) {
+		case 0:
+		case 1:
+			return isStruct(members[0].Schema())
+		default:
+			return true
+		}
+	}
+	return isStruct(schema)
+}
+
+// mapsToNamedType reports whether schema is mapped to the Date or Decimal
+// type, to a type of another package, such as time.Time, or to the type of
+// its x-go-type extension, which omitempty never leaves out.
+func (g *generator) mapsToNamedType(schema *base.Schema) bool {
+	if target := extension(schema, goTypeExtension); target != "" {
+		return strings.Contains(target, ".") || extension(schema, goTypeImportExtension) != ""
+	}
+	if schema == nil {
+		return false
+	}
+	target, ok := g.typeMapping(schema)
+	if target == decimalType {
+		return schemaType(schema) == "number"
+	}
+	return ok && (target == dateType || strings.Contains(target, "."))
+}
+
+// isNullable reports whether schema admits null: with "nullable: true" in
+// OpenAPI 3.0, or with a null type or oneOf or anyOf member in 3.1.
+func isNullable(schema *base.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if schema.Nullable != nil && *schema.Nullable || slices.Contains(schema.Type, "null") {
+		return true
+	}
+	for _, members := range [][]*base.SchemaProxy{schema.OneOf, schema.AnyOf} {
+		if len(nonNullMembers(members)) < len(members) {
+			return true
+		}
+	}
+	return false
+}
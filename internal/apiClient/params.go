@@ -0,0 +1,428 @@
+package apiClient
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// paramField describes the field of a parameter in the Params struct of an
+// operation, and how it is encoded after its style and explode.
+type paramField struct {
+	// Name is the name of the parameter, In its location, Field the name of
+	// its field, of the Go type Type, and Doc the doc comment of the field.
+	Name       string
+	In         string
+	Field      string
+	Type       string
+	Doc        string
+	Deprecated bool
+	// Guard is the condition under which an optional field is set, and
+	// Value the expression of its value. Missing is the condition under
+	// which a required path, header or cookie parameter is missing, if it
+	// can be told.
+	Guard   string
+	Value   string
+	Missing string
+	// Kind is "value", "list", "map" or "struct". Elem formats the value,
+	// or else an element or map value e, as a string.
+	Kind string
+	Elem string
+	// Add is the call adding a name and a value to the request, such as
+	// query.Add or addCookie. Delimiter joins the elements of a list, or the keys and
+	// values of an object, that is not exploded; Joined joins each key to
+	// its value with "=" instead. Deep encodes the properties of an object
+	// as name[key]=value, as deepObject has it.
+	Add       string
+	Delimiter string
+	Joined    bool
+	Deep      bool
+	// Props are the properties of a struct, read from its value v.
+	Props []paramProp
+
+	// schema is the schema of the parameter, and optional reports whether
+	// it is not required, for buildValidation.
+	schema   *base.Schema
+	optional bool
+}
+
+// paramProp is a property of a struct parameter, set when Guard holds,
+// whose value Value formats as a string.
+type paramProp struct {
+	Key   string
+	Guard string
+	Value string
+}
+
+// ParamFields returns the fields of the Params struct of o: its path,
+// query, header and cookie parameters.
+func (o operationData) ParamFields() []paramField {
+	return slices.Concat(o.PathParams, o.Query, o.Headers, o.Cookies)
+}
+
+// OptionalParams reports whether o has an optional parameter, whose field
+// is left out when nil.
+func (o operationData) OptionalParams() bool {
+	return slices.ContainsFunc(o.ParamFields(), func(f paramField) bool { return f.optional })
+}
+
+// DefaultOptions returns the Defaults of o as the elements of a slice.
+func (o operationData) DefaultOptions() string {
+	return strings.Join(o.Defaults, ", ")
+}
+
+// operationParameters returns the parameters of op followed by those of its
+// path item that op does not override with the same name and location.
+func operationParameters(item *v3.PathItem, op *v3.Operation) []*v3.Parameter {
+	params := append([]*v3.Parameter(nil), op.Parameters...)
+	for _, p := range item.Parameters {
+		overridden := false
+		for _, q := range op.Parameters {
+			if q.Name == p.Name && q.In == p.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// pathPart is a part of a path: a literal text, or the {template} of the
+// parameter named Text.
+type pathPart struct {
+	Text     string
+	Template bool
+}
+
+// splitPath splits path into its literal texts and templates. A template
+// may appear once.
+func splitPath(path string) ([]pathPart, error) {
+	var parts []pathPart
+	used := map[string]bool{}
+	rest := path
+	for rest != "" {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			parts = append(parts, pathPart{Text: rest})
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated path template in %s", path)
+		}
+		if start > 0 {
+			parts = append(parts, pathPart{Text: rest[:start]})
+		}
+		name := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+		if used[name] {
+			return nil, fmt.Errorf("path template {%s} repeats", name)
+		}
+		used[name] = true
+		parts = append(parts, pathPart{Text: name, Template: true})
+	}
+	return parts, nil
+}
+
+// buildPathExpr sets the expression of the path of data, with every
+// template such as {petId} replaced by the escaped value of its parameter.
+// A template without a parameter fails generation.
+func buildPathExpr(data *operationData, parts []pathPart) error {
+	var expr []string
+	for _, part := range parts {
+		if !part.Template {
+			expr = append(expr, strconv.Quote(part.Text))
+			continue
+		}
+		i := slices.IndexFunc(data.PathParams, func(f paramField) bool { return f.Name == part.Text })
+		if i < 0 {
+			return fmt.Errorf("path template {%s} has no path parameter", part.Text)
+		}
+		expr = append(expr, "pathEscape("+data.PathParams[i].Elem+")")
+	}
+	if len(expr) == 0 {
+		expr = []string{`""`}
+	}
+	data.PathExpr = strings.Join(expr, " + ")
+	return nil
+}
+
+// buildParams declares the Params struct of data, such as GetPetParams,
+// with a field per parameter, and sets the expression of its path. Path
+// parameters without a template in path, and header parameters named
+// Accept, Content-Type or Authorization, are ignored, as OpenAPI has it.
+func (g *generator) buildParams(data *operationData, path string, params []*v3.Parameter) error {
+	parts, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	var fields []*v3.Parameter
+	for _, p := range params {
+		switch p.In {
+		case "path":
+			if !slices.Contains(parts, pathPart{Text: p.Name, Template: true}) {
+				g.log().Warn("path parameter not in path, ignored", "operation", data.Name, "parameter", p.Name)
+				continue
+			}
+		case "query", "cookie":
+		case "header":
+			switch http.CanonicalHeaderKey(p.Name) {
+			case "Accept", "Content-Type", "Authorization":
+				g.log().Debug("ignored header parameter", "operation", data.Name, "parameter", p.Name)
+				continue
+			case "Idempotency-Key":
+				data.Defaults = append(data.Defaults, g.qualifier+"Idempotent()")
+			}
+		default:
+			continue
+		}
+		fields = append(fields, p)
+	}
+	if len(fields) == 0 {
+		return buildPathExpr(data, parts)
+	}
+	name := g.typeName(data.Name + "Params")
+	if free := freeName(name, g.models); free != name {
+		g.renamed("type", data.Name+" parameters", name, free)
+		name = free
+	}
+	g.models[name] = true
+	data.Params = name
+	taken := map[string]bool{}
+	for _, p := range fields {
+		f := g.paramField(data, p, taken)
+		switch p.In {
+		case "path":
+			data.PathParams = append(data.PathParams, f)
+		case "query":
+			data.Query = append(data.Query, f)
+		case "header":
+			data.Headers = append(data.Headers, f)
+		default:
+			data.Cookies = append(data.Cookies, f)
+		}
+	}
+	return buildPathExpr(data, parts)
+}
+
+// paramField returns the field of the parameter p, named unlike the fields
+// in taken, to which it is added. Optional parameters are pointers unless
+// their type has a nil value of its own; path parameters are required.
+func (g *generator) paramField(data *operationData, p *v3.Parameter, taken map[string]bool) paramField {
+	field := g.goName(p.Name)
+	if field == "" {
+		field = "Field"
+	}
+	field = freeName(field, taken)
+	taken[field] = true
+
+	g.at, g.typeAt = data.Name+" "+p.In+" parameter "+p.Name, data.Name+field
+	typ := "string"
+	if p.Schema != nil {
+		typ = g.goType(p.Schema)
+	} else {
+		g.degraded("string", "parameter has no schema")
+	}
+	f := paramField{
+		Name:       p.Name,
+		In:         p.In,
+		Field:      field,
+		Type:       typ,
+		Doc:        strings.TrimSpace(p.Description),
+		Deprecated: p.Deprecated,
+		Value:      "params." + field,
+	}
+	required := p.Required != nil && *p.Required || p.In == "path"
+	f.optional = !required
+	if p.Schema != nil {
+		f.schema = p.Schema.Schema()
+	}
+	switch {
+	case !required:
+		f.Guard = f.Value + " != nil"
+		if !isNilable(typ) {
+			f.Type, f.Value = "*"+typ, "*"+f.Value
+		}
+	case p.In == "query":
+	case typ == "string":
+		f.Missing = f.Value + ` == ""`
+	case isNilable(typ):
+		f.Missing = f.Value + " == nil"
+	}
+
+	if p.In == "path" {
+		// Path parameters are single values, in the simple style.
+		value, ok := paramValue(f.Value, typ)
+		if !ok {
+			g.degraded("", "cannot format a parameter of type "+typ+", formatted with fmt.Sprint")
+		}
+		f.Kind, f.Elem = "value", value
+		return f
+	}
+
+	// Query and cookie parameters default to the form style and headers to
+	// simple.
+	style, explode := p.Style, false
+	switch {
+	case p.In == "header":
+		f.Add = "req.Header.Set"
+		if style != "" && style != "simple" {
+			g.degraded("", "style "+style+" of a header parameter is not supported, encoded as simple")
+		}
+		style = "simple"
+	case p.In == "cookie":
+		f.Add = "addCookie"
+		if style != "" && style != "form" {
+			g.degraded("", "style "+style+" of a cookie parameter is not supported, encoded as form")
+		}
+		style, explode = "form", true
+	case style == "":
+		style = "form"
+		fallthrough
+	default:
+		f.Add = "query.Add"
+		explode = style == "form"
+	}
+	if p.Explode != nil {
+		explode = *p.Explode
+	}
+	qualifier := g.qualifier
+	format := func(expr, typ string) string {
+		value, ok := paramValue(expr, typ)
+		if !ok {
+			g.degraded("", "cannot format a parameter of type "+typ+", formatted with fmt.Sprint")
+		}
+		return value
+	}
+	switch fields, fieldQualifier, isStruct := g.fieldsOf(strings.TrimPrefix(typ, qualifier)); {
+	case strings.HasPrefix(typ, "[]"):
+		f.Kind, f.Elem = "list", format("e", strings.TrimPrefix(typ, "[]"))
+		delimiter := map[string]string{"form": ",", "simple": ",", "spaceDelimited": " ", "pipeDelimited": "|"}[style]
+		if delimiter == "" {
+			g.degraded("", "style "+style+" of an array query parameter is not supported, encoded as form")
+			delimiter = ","
+		}
+		if !explode || style == "simple" {
+			f.Delimiter = delimiter
+		}
+	case strings.HasPrefix(typ, "map[string]"):
+		f.Kind, f.Elem = "map", format("e", strings.TrimPrefix(typ, "map[string]"))
+		g.objectStyle(&f, style, explode)
+	case isStruct:
+		f.Kind = "struct"
+		for _, sf := range fields {
+			c, ftyp, ok := fieldValue(sf, fieldQualifier)
+			if sf.Embedded || !ok {
+				g.degraded("", "property "+sf.JSONName+" of a parameter is left out")
+				continue
+			}
+			// A property is left out when its JSON encoding would be.
+			if c.Guard == "" && sf.Omit == "omitempty" {
+				c.Guard = emptyGuard(sf)
+			}
+			f.Props = append(f.Props, paramProp{Key: sf.JSONName, Guard: c.Guard, Value: format(c.Value, ftyp)})
+		}
+		g.objectStyle(&f, style, explode)
+	default:
+		f.Kind, f.Elem = "value", format(f.Value, typ)
+	}
+	return f
+}
+
+// emptyGuard returns the condition under which the field f, held by value
+// and tagged omitempty, is not empty, and so encoded by encoding/json.
+func emptyGuard(f fieldData) string {
+	field := "v." + f.Name
+	switch {
+	case strings.HasPrefix(f.Type, "[]") || strings.HasPrefix(f.Type, "map["):
+		return "len(" + field + ") > 0"
+	case f.schema == nil:
+		return ""
+	}
+	switch schemaType(constraintSchema(f.schema)) {
+	case "string":
+		return field + ` != ""`
+	case "integer", "number":
+		return field + " != 0"
+	case "boolean":
+		return field
+	}
+	return ""
+}
+
+// objectStyle sets how the object parameter f is encoded: as
+// name[key]=value pairs for deepObject, as key=value pairs for an exploded
+// form, as key=value items joined by commas for an exploded simple style,
+// or else as the keys and values joined by commas.
+func (g *generator) objectStyle(f *paramField, style string, explode bool) {
+	switch style {
+	case "deepObject":
+		f.Deep = true
+		return
+	case "simple":
+		f.Delimiter, f.Joined = ",", explode
+		return
+	case "form":
+	default:
+		g.degraded("", "style "+style+" of an object query parameter is not supported, encoded as form")
+	}
+	if !explode {
+		f.Delimiter = ","
+	}
+}
+
+// fieldsOf returns the fields of the struct model name, declared by
+// buildModels or pending, the qualifier of the types of those fields, and
+// whether there is one. Pending types go with the current operation.
+func (g *generator) fieldsOf(name string) ([]fieldData, string, bool) {
+	for _, m := range g.pending {
+		if m.Name == name {
+			return m.Fields, g.qualifier, m.Struct
+		}
+	}
+	fields, ok := g.structs[name]
+	return fields, "", ok
+}
+
+// paramValue returns the Go expression formatting expr, of the Go type typ,
+// as the string of a parameter, and false if it falls back to fmt.Sprint
+// for a type without a textual form of its own.
+func paramValue(expr, typ string) (string, bool) {
+	switch typ {
+	case "string":
+		return expr, true
+	case "int":
+		return "strconv.Itoa(" + expr + ")", true
+	case "int64":
+		return "strconv.FormatInt(" + expr + ", 10)", true
+	case "int8", "int16", "int32":
+		return "strconv.FormatInt(int64(" + expr + "), 10)", true
+	case "uint64":
+		return "strconv.FormatUint(" + expr + ", 10)", true
+	case "uint", "uint8", "uint16", "uint32":
+		return "strconv.FormatUint(uint64(" + expr + "), 10)", true
+	case "float32":
+		return "strconv.FormatFloat(float64(" + expr + "), 'g', -1, 32)", true
+	case "float64":
+		return "strconv.FormatFloat(" + expr + ", 'g', -1, 64)", true
+	case "bool":
+		return "strconv.FormatBool(" + expr + ")", true
+	case "time.Time":
+		if strings.HasPrefix(expr, "*") {
+			expr = "(" + expr + ")"
+		}
+		return expr + ".Format(time.RFC3339)", true
+	case "[]string":
+		return `strings.Join(` + expr + `, ",")`, true
+	}
+	// Enums, Date and types such as uuid.UUID print as their text.
+	return "fmt.Sprint(" + expr + ")", !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") && typ != "interface{}"
+}
@@ -0,0 +1,160 @@
+package apiClient
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const escapeSpec = `
+openapi: 3.0.3
+info: {title: escape, version: "1"}
+paths:
+  /items/{name}:
+    get:
+      operationId: getItem
+      parameters:
+        - {name: name, in: path, required: true, schema: {type: string}}
+        - {name: q, in: query, required: true, schema: {type: string}}
+      responses:
+        "204": {description: ok}
+`
+
+// escapeMain prints the escaped path and the raw query of the request of
+// each value given as both the path and the query parameter, once per line.
+const escapeMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL+"/v1", client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		fmt.Println(req.URL.EscapedPath(), req.URL.RawQuery, req.URL.Query().Get("q"))
+		return nil
+	}))
+	for _, v := range values {
+		if _, err := c.GetItem(context.Background(), client.GetItemParams{Name: v, Q: v}); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+var values = %s
+`
+
+// TestParamEscaping checks that hostile values of path and query
+// parameters stay within their segment and parameter.
+func TestParamEscaping(t *testing.T) {
+	tests := []struct {
+		value string
+		path  string
+		query string
+	}{
+		{"a/b", "/v1/items/a%2Fb", "q=a%2Fb"},
+		{"a&b", "/v1/items/a&b", "q=a%26b"},
+		{"a?b", "/v1/items/a%3Fb", "q=a%3Fb"},
+		{"a#b", "/v1/items/a%23b", "q=a%23b"},
+		{"a b", "/v1/items/a%20b", "q=a+b"},
+		{"100%", "/v1/items/100%25", "q=100%25"},
+		{".", "/v1/items/%2E", "q=."},
+		{"..", "/v1/items/%2E%2E", "q=.."},
+		{"../admin", "/v1/items/..%2Fadmin", "q=..%2Fadmin"},
+		{"héllo", "/v1/items/h%C3%A9llo", "q=h%C3%A9llo"},
+	}
+	var values []string
+	for _, tt := range tests {
+		values = append(values, fmt.Sprintf("%q", tt.value))
+	}
+	out := runGenerated(t, escapeSpec, fmt.Sprintf(escapeMain, "[]string{"+strings.Join(values, ", ")+"}"))
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != len(tests) {
+		t.Fatalf("got %d requests, want %d:\n%s", len(lines), len(tests), out)
+	}
+	for i, tt := range tests {
+		want := tt.path + " " + tt.query + " " + tt.value
+		if lines[i] != want {
+			t.Errorf("value %q: got %q, want %q", tt.value, lines[i], want)
+		}
+	}
+}
+
+const objectQuerySpec = `
+openapi: 3.0.3
+info: {title: object query, version: "1"}
+paths:
+  /near:
+    get:
+      operationId: near
+      parameters:
+        - name: point
+          in: query
+          required: true
+          schema:
+            type: object
+            required: [lat, exact]
+            properties:
+              lat: {type: number}
+              exact: {type: boolean}
+              radius: {type: integer}
+              open: {type: boolean}
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            required: [min]
+            properties:
+              min: {type: integer}
+              tags: {type: array, items: {type: string}}
+      responses:
+        "204": {description: ok}
+`
+
+const objectQueryMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println(r.URL.RawQuery)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL)
+	ctx := context.Background()
+	c.Near(ctx, client.NearParams{Filter: &client.NearFilter{Tags: []string{}}})
+	c.Near(ctx, client.NearParams{
+		Point:  client.NearPoint{Lat: 1.5, Exact: true, Radius: 2, Open: true},
+		Filter: &client.NearFilter{Min: 3, Tags: []string{"a"}},
+	})
+}
+`
+
+// TestObjectQueryParams checks that the required properties of object
+// query parameters are sent even when zero, and optional ones only when
+// their JSON encoding would hold them.
+func TestObjectQueryParams(t *testing.T) {
+	got := runGenerated(t, objectQuerySpec, objectQueryMain)
+	want := "exact=false&filter%5Bmin%5D=0&lat=0\n" +
+		"exact=true&filter%5Bmin%5D=3&filter%5Btags%5D=a&lat=1.5&open=true&radius=2\n"
+	if got != want {
+		t.Errorf("queries:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package apiClient
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sync"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// A Pass is a hook run during generation, either a PrePass or a PostPass.
+type Pass interface {
+	isPass()
+}
+
+// PrePass is run on the parsed document before any code is generated. It
+// may modify the document, e.g. to add or rewrite schemas.
+type PrePass func(doc *v3.Document) error
+
+// PostPass is run on every generated Go file before it is formatted. name
+// is the File.Name of the file. Passes may modify the syntax tree, e.g. to
+// inject helpers; the import block is recomputed afterwards, as for code
+// rendered by the templates. Files are processed concurrently, so a
+// PostPass must be safe to call from several goroutines at once.
+type PostPass func(name string, fset *token.FileSet, file *ast.File) error
+
+func (PrePass) isPass()  {}
+func (PostPass) isPass() {}
+
+// passes holds the hooks added with Register.
+var passes struct {
+	sync.Mutex
+	pre  []PrePass
+	post []PostPass
+}
+
+// Register adds a pass to every subsequent generation run. Passes run in
+// the order they were registered. It is typically called from an init
+// function or at the start of main.
+func Register(p Pass) {
+	passes.Lock()
+	defer passes.Unlock()
+	switch p := p.(type) {
+	case PrePass:
+		passes.pre = append(passes.pre, p)
+	case PostPass:
+		passes.post = append(passes.post, p)
+	default:
+		panic(fmt.Sprintf("apiClient: Register of unknown pass type %T", p))
+	}
+}
+
+func registeredPasses() ([]PrePass, []PostPass) {
+	passes.Lock()
+	defer passes.Unlock()
+	return passes.pre, passes.post
+}
+
+func runPrePasses(doc *v3.Document) error {
+	pre, _ := registeredPasses()
+	for _, p := range pre {
+		if err := p(doc); err != nil {
+			return fmt.Errorf("pre-pass: %w", err)
+		}
+	}
+	return nil
+}
+
+// runPostPasses parses src, runs the post passes on it and prints the
+// result. src is returned unchanged when no post pass is registered.
+func runPostPasses(name string, src []byte) ([]byte, error) {
+	_, post := registeredPasses()
+	if len(post) == 0 {
+		return src, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated code: %w", err)
+	}
+	for _, p := range post {
+		if err := p(name, fset, file); err != nil {
+			return nil, fmt.Errorf("post-pass: %w", err)
+		}
+	}
+	var b bytes.Buffer
+	if err := format.Node(&b, fset, file); err != nil {
+		return nil, fmt.Errorf("printing post-pass result: %w", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,148 @@
+package apiClient
+
+import "testing"
+
+const pollerSpec = `
+openapi: 3.0.3
+info: {title: poller, version: "1"}
+paths:
+  /reports:
+    post:
+      operationId: createReport
+      parameters:
+        - {name: outcome, in: query, required: true, schema: {type: string}}
+      responses:
+        "202":
+          description: accepted
+          headers:
+            Operation-Location: {schema: {type: string}}
+        "200":
+          description: done
+          content:
+            application/json:
+              schema: {type: object, properties: {id: {type: string}}}
+`
+
+const pollerMain = `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	var mu sync.Mutex
+	polls := map[string]int{}
+	var last time.Time
+	var minGap time.Duration
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outcome := r.URL.Query().Get("outcome")
+		if r.Method == http.MethodPost {
+			if outcome == "now" {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, "{\"id\":\"now\"}")
+				return
+			}
+			if outcome != "interval" {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.Header().Set("Operation-Location", "/status?outcome="+outcome)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		mu.Lock()
+		polls[outcome]++
+		n := polls[outcome]
+		if now := time.Now(); outcome == "interval" && !last.IsZero() && (minGap == 0 || now.Sub(last) < minGap) {
+			minGap = now.Sub(last)
+		}
+		last = time.Now()
+		mu.Unlock()
+		if outcome != "interval" {
+			w.Header().Set("Retry-After", "0")
+		}
+		switch {
+		case n == 1:
+			w.WriteHeader(http.StatusAccepted)
+		case n == 2:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, "{\"status\":\"Running\"}")
+		case outcome == "fail":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, "{\"status\":\"Failed\"}")
+		case outcome == "error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, "{\"status\":\"Succeeded\",\"id\":\""+strconv.Itoa(n)+"\"}")
+		}
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL)
+	ctx := context.Background()
+	for _, outcome := range []string{"now", "succeed", "fail", "error", "interval"} {
+		p, err := c.CreateReportPoller(ctx, client.CreateReportParams{Outcome: outcome})
+		if err != nil {
+			fmt.Println(outcome, "error", err)
+			continue
+		}
+		fmt.Println(outcome, "done", p.Done())
+		// A Retry-After of 0 overrides the interval, which only the
+		// last outcome waits for.
+		p.Interval = time.Hour
+		if outcome == "interval" {
+			p.Interval = 50 * time.Millisecond
+		}
+		body, err := p.Wait(ctx)
+		var respErr *client.ResponseError
+		switch {
+		case errors.Is(err, client.ErrOperationFailed):
+			fmt.Println(outcome, "failed", p.Status())
+		case errors.As(err, &respErr):
+			fmt.Println(outcome, "status", respErr.StatusCode)
+		case err != nil:
+			fmt.Println(outcome, "error", err)
+		default:
+			fmt.Println(outcome, string(body), p.Status(), p.Done())
+		}
+		fmt.Println(outcome, "polls", polls[outcome])
+	}
+	fmt.Println("waited", minGap >= 50*time.Millisecond)
+
+	p, err := c.CreateReportPoller(ctx, client.CreateReportParams{Outcome: "interval"})
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	p.Interval = time.Hour
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = p.Wait(timeout)
+	fmt.Println("canceled", errors.Is(err, context.DeadlineExceeded), p.Done())
+}
+`
+
+// TestPoller checks that a Poller follows the status URL of a long-running
+// operation until a status is done, succeeded, failed or an error, waiting
+// for the Retry-After of each status or else its interval, and that Wait
+// stops with its context.
+func TestPoller(t *testing.T) {
+	got := runGenerated(t, pollerSpec, pollerMain)
+	want := "now done true\nnow {\"id\":\"now\"}  true\nnow polls 0\n" +
+		"succeed done false\nsucceed {\"status\":\"Succeeded\",\"id\":\"3\"} Succeeded true\nsucceed polls 3\n" +
+		"fail done false\nfail failed Failed\nfail polls 3\n" +
+		"error done false\nerror status 500\nerror polls 3\n" +
+		"interval done false\ninterval {\"status\":\"Succeeded\",\"id\":\"3\"} Succeeded true\ninterval polls 3\n" +
+		"waited true\ncanceled true false\n"
+	if got != want {
+		t.Errorf("polls:\n%s\nwant:\n%s", got, want)
+	}
+}
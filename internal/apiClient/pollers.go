@@ -0,0 +1,54 @@
+package apiClient
+
+import (
+	"net/textproto"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// pollerType, newPollerFunc and errOperationFailed are the names of the
+// type following a long-running operation, of its constructor and of the
+// error of a failed operation, emitted alongside the client.
+const (
+	pollerType         = "Poller"
+	newPollerFunc      = "NewPoller"
+	errOperationFailed = "ErrOperationFailed"
+)
+
+// buildPoller adds to data the method returning a Poller of the
+// long-running operation that op starts, such as CreateReportPoller, if
+// its 202 Accepted response declares an Operation-Location or Location
+// header giving the status of the operation.
+func (g *generator) buildPoller(data *operationData, op *v3.Operation) {
+	if op.Responses == nil {
+		return
+	}
+	accepted, ok := op.Responses.Codes.Get("202")
+	if !ok || accepted == nil || accepted.Headers == nil {
+		return
+	}
+	var location bool
+	for name := range accepted.Headers.KeysFromOldest() {
+		switch textproto.CanonicalMIMEHeaderKey(name) {
+		case "Operation-Location", "Location":
+			location = true
+		}
+	}
+	if !location {
+		return
+	}
+	if !g.usesPollers && (g.models[pollerType] || g.models[newPollerFunc] || g.models[errOperationFailed]) {
+		g.log().Warn("type name for long-running operations is taken: no poller method", "operation", data.Name, "type", pollerType)
+		return
+	}
+	g.usesPollers = true
+	g.models[pollerType], g.models[newPollerFunc], g.models[errOperationFailed] = true, true, true
+	data.PollerType, data.NewPollerFunc = g.qualifier+pollerType, g.qualifier+newPollerFunc
+
+	data.Poller = data.Name + "Poller"
+	if free := freeName(data.Poller, g.methods); free != data.Poller {
+		g.renamed("operation", data.Method+" "+data.Path+" poller", data.Poller, free)
+		data.Poller = free
+	}
+	g.methods[data.Poller] = true
+}
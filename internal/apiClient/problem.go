@@ -0,0 +1,47 @@
+package apiClient
+
+import (
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// problemMediaType is the media type of the problem details of RFC 7807.
+const problemMediaType = "application/problem+json"
+
+// problemType is the name of the type generated for problem details.
+const problemType = "ProblemDetails"
+
+// isProblem reports whether content documents problem details as its JSON
+// body without a component schema of its own, which the body is then
+// decoded into.
+func isProblem(content *orderedmap.Map[string, *v3.MediaType]) bool {
+	if _, ok := content.Get(jsonMediaType); ok {
+		return false
+	}
+	// The first JSON media type is the one jsonContent picks.
+	for name, mt := range content.FromOldest() {
+		name, _, _ = strings.Cut(name, ";")
+		switch name = strings.TrimSpace(name); {
+		case name == problemMediaType:
+			return mt.Schema == nil || !mt.Schema.IsReference()
+		case name == jsonMediaType || strings.HasSuffix(name, "+json"):
+			return false
+		}
+	}
+	return false
+}
+
+// problemType returns the generated ProblemDetails type, which is then
+// emitted alongside the client, or false if a schema already takes its
+// name.
+func (g *generator) problemType() (string, bool) {
+	if !g.usesProblem && g.models[problemType] {
+		g.log().Warn("type name for problem details is taken, decoding them as documented", "schema", g.at, "type", problemType)
+		return "", false
+	}
+	g.usesProblem = true
+	g.models[problemType] = true
+	return g.qualifier + problemType, true
+}
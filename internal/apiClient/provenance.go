@@ -0,0 +1,105 @@
+package apiClient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// provenance identifies what generated code was built from. It is emitted
+// as constants next to the client and summarised in the file header.
+type provenance struct {
+	GeneratorVersion string
+	SpecTitle        string
+	SpecVersion      string
+	// SpecHash is "sha256:" followed by the hex digest of the document and
+	// the files it references, or empty if unknown.
+	SpecHash string
+}
+
+func newProvenance(spec *Spec) provenance {
+	p := provenance{
+		GeneratorVersion: generatorVersion(),
+		SpecHash:         spec.Hash,
+	}
+	if info := spec.Document.Info; info != nil {
+		p.SpecTitle = info.Title
+		p.SpecVersion = info.Version
+	}
+	return p
+}
+
+// marker returns the generated-code comment for the file header, e.g.
+// "// Code generated by oasgen v1.2.0 from Petstore 1.0.0 (sha256:0123456789ab). DO NOT EDIT."
+func (p provenance) marker() string {
+	var b strings.Builder
+	b.WriteString("// Code generated by oasgen")
+	if p.GeneratorVersion != "" {
+		b.WriteString(" " + p.GeneratorVersion)
+	}
+	if from := strings.TrimSpace(strings.Join([]string{p.SpecTitle, p.SpecVersion}, " ")); from != "" {
+		b.WriteString(" from " + strings.Join(strings.Fields(from), " "))
+	}
+	if hash, ok := strings.CutPrefix(p.SpecHash, "sha256:"); ok && len(hash) >= 12 {
+		fmt.Fprintf(&b, " (sha256:%s)", hash[:12])
+	}
+	b.WriteString(". DO NOT EDIT.")
+	return b.String()
+}
+
+// UserAgent returns the default User-Agent header of the generated client,
+// naming the API after its title and version and the generator, such as
+// "swagger-petstore/1.0.0 oasgen/v1.2.0".
+func (p provenance) UserAgent() string {
+	product := strings.Trim(strings.Map(func(r rune) rune {
+		if 'a' <= r && r <= 'z' || '0' <= r && r <= '9' || r == '.' {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(p.SpecTitle)), "-.")
+	for strings.Contains(product, "--") {
+		product = strings.ReplaceAll(product, "--", "-")
+	}
+	if product == "" {
+		product = "client"
+	}
+	if version := strings.Join(strings.Fields(p.SpecVersion), ""); version != "" {
+		product += "/" + version
+	}
+	generator := "oasgen"
+	if strings.HasPrefix(p.GeneratorVersion, "v") {
+		generator += "/" + p.GeneratorVersion
+	}
+	return product + " " + generator
+}
+
+// hashSpec returns the SpecHash of a document and of the files it
+// references, in order.
+func hashSpec(doc []byte, refs []string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00", len(doc))
+	h.Write(doc)
+	for _, path := range refs {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%d\x00", len(data))
+		h.Write(data)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// generatorVersion returns the module version oasgen was built at, such
+// as "v1.2.0", or "(devel)" for builds outside of a module version.
+var generatorVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" {
+		return "(devel)"
+	}
+	return info.Main.Version
+})
@@ -0,0 +1,225 @@
+package apiClient
+
+import (
+	"regexp"
+	"strings"
+)
+
+// builderData is a method of a Params struct setting query parameters of
+// the filter, sort and fields conventions. It changes the struct p it is
+// called on and returns it, so that calls chain.
+type builderData struct {
+	Name string
+	Doc  string
+	Args string
+	// Body are the statements of the method, which end by returning p.
+	Body []string
+}
+
+// bracketParam matches the names of query parameters such as
+// filter[status] and fields[articles].
+var bracketParam = regexp.MustCompile(`^(filter|fields)\[([^\[\]]+)\]$`)
+
+// buildQueryBuilders adds to the Params struct of data the methods setting
+// its query parameters that follow the common conventions:
+// WithFilterColor for the property color of a filter object in the
+// deepObject style, or for a filter[color] parameter, and WithSort,
+// WithSortDesc and WithFields for lists of keys in sort, fields and
+// fields[type]. Methods whose name a field takes are left out.
+func (g *generator) buildQueryBuilders(data *operationData) {
+	taken := map[string]bool{"Validate": true}
+	for _, f := range data.ParamFields() {
+		taken[f.Field] = true
+	}
+	add := func(b builderData) {
+		if taken[b.Name] {
+			g.log().Debug("query builder method name is taken, skipping it", "operation", data.Name, "method", b.Name)
+			return
+		}
+		taken[b.Name] = true
+		b.Doc = wrapText(b.Doc, 76)
+		data.Builders = append(data.Builders, b)
+	}
+	for _, f := range data.Query {
+		field := "p." + f.Field
+		name := f.Name
+		if m := bracketParam.FindStringSubmatch(f.Name); m != nil {
+			name = m[1]
+		}
+		switch {
+		case name == "filter" && f.Kind == "value" && f.Name != name:
+			typ := strings.TrimPrefix(f.Type, "*")
+			b := builderData{Name: "With" + f.Field, Args: "value " + typ}
+			b.Doc = b.Name + " returns p filtering by " + f.Name + "=value."
+			b.Body = []string{assignStatement(field, f.Type, g.qualifier, "value"), "return p"}
+			add(b)
+		case name == "filter" && f.Kind == "struct" && f.Deep:
+			g.filterBuilders(f, add)
+		case name == "filter" && f.Kind == "map" && f.Deep:
+			elem := strings.TrimPrefix(f.Type, "map[string]")
+			b := builderData{Name: "WithFilter", Args: "key string, value " + elem}
+			b.Doc = b.Name + " returns p filtering by filter[key]=value."
+			b.Body = []string{
+				"filter := maps.Clone(" + field + ")",
+				"if filter == nil {\nfilter = " + f.Type + "{}\n}",
+				"filter[key] = value",
+				field + " = filter",
+				"return p",
+			}
+			add(b)
+		case name == "sort" || name == "fields":
+			keys := "keys"
+			if name == "fields" {
+				keys = "names"
+			}
+			b, elem, ok := listBuilder(f, keys)
+			if !ok {
+				continue
+			}
+			if name == "sort" {
+				b.Doc = b.Name + " returns p sorted by keys too, after those it is sorted by already. A key prefixed with - sorts in descending order."
+			} else {
+				b.Doc = b.Name + " returns p selecting the " + f.Name + " names too."
+			}
+			add(b)
+			if name == "sort" && elem == "string" {
+				desc := builderData{Name: b.Name + "Desc", Args: "key string"}
+				desc.Doc = desc.Name + " returns p sorted by key in descending order too."
+				desc.Body = []string{"return p." + b.Name + `("-" + key)`}
+				add(desc)
+			}
+		}
+	}
+}
+
+// filterBuilders adds a method per property of the filter object f, such
+// as WithFilterColor setting filter[color].
+func (g *generator) filterBuilders(f paramField, add func(builderData)) {
+	fields, fieldQualifier, _ := g.fieldsOf(strings.TrimPrefix(strings.TrimPrefix(f.Type, "*"), g.qualifier))
+	for _, sf := range fields {
+		if sf.Embedded {
+			continue
+		}
+		if _, _, ok := fieldValue(sf, fieldQualifier); !ok {
+			continue
+		}
+		typ := sf.Type
+		if fieldQualifier != g.qualifier {
+			// The fields of a component type read from a per-tag package.
+			typ = qualifyType(typ, g.qualifier)
+		}
+		elem, ok := heldType(typ, g.qualifier)
+		if !ok {
+			continue
+		}
+		b := builderData{Name: "WithFilter" + sf.Name, Args: "value " + elem}
+		b.Doc = b.Name + " returns p filtering by " + f.Name + "[" + sf.JSONName + "]=value."
+		if strct, ok := strings.CutPrefix(f.Type, "*"); ok {
+			b.Body = []string{
+				"var filter " + strct,
+				"if p." + f.Field + " != nil {\nfilter = *p." + f.Field + "\n}",
+				assignStatement("filter."+sf.Name, typ, g.qualifier, "value"),
+				"p." + f.Field + " = &filter",
+				"return p",
+			}
+		} else {
+			b.Body = []string{assignStatement("p."+f.Field+"."+sf.Name, typ, g.qualifier, "value"), "return p"}
+		}
+		add(b)
+	}
+}
+
+// listBuilder returns the method adding keys to the list parameter f, held
+// as a slice or as a string joining them with commas, along with the type
+// of the keys.
+func listBuilder(f paramField, keys string) (builderData, string, bool) {
+	field := "p." + f.Field
+	b := builderData{Name: "With" + f.Field}
+	switch {
+	case f.Kind == "list":
+		elem := strings.TrimPrefix(f.Type, "[]")
+		b.Args = keys + " ..." + elem
+		b.Body = []string{field + " = append(slices.Clip(" + field + "), " + keys + "...)", "return p"}
+		return b, elem, true
+	case f.Type == "string":
+		b.Args = keys + " ...string"
+		b.Body = []string{
+			"list := strings.Join(" + keys + `, ",")`,
+			"if " + field + ` != "" {` + "\nlist = " + field + ` + "," + list` + "\n}",
+			field + " = list",
+			"return p",
+		}
+		return b, "string", true
+	case f.Type == "*string":
+		b.Args = keys + " ...string"
+		b.Body = []string{
+			"list := strings.Join(" + keys + `, ",")`,
+			"if " + field + " != nil && *" + field + ` != "" {` + "\nlist = *" + field + ` + "," + list` + "\n}",
+			field + " = &list",
+			"return p",
+		}
+		return b, "string", true
+	}
+	return builderData{}, "", false
+}
+
+// heldType returns the type of the values held by a field of the type
+// typ: the element of a pointer, Optional or Null, or typ itself. Fields of
+// other types of nil value, such as slices, have no single value.
+func heldType(typ, qualifier string) (string, bool) {
+	if elem, ok := strings.CutPrefix(typ, "*"); ok {
+		return elem, true
+	}
+	for _, wrapper := range []string{"Optional[", "Null["} {
+		if inner, ok := strings.CutPrefix(typ, qualifier+wrapper); ok {
+			return strings.TrimSuffix(inner, "]"), true
+		}
+	}
+	return typ, !isNilable(typ)
+}
+
+// assignStatement returns the statement setting the field, an expression
+// of the type typ, to value, which is of its heldType. Optional and Null
+// are declared in the package qualifier names.
+func assignStatement(field, typ, qualifier, value string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		return field + " = &" + value
+	case strings.HasPrefix(typ, qualifier+"Optional["):
+		return field + " = " + qualifier + "Some(" + value + ")"
+	case strings.HasPrefix(typ, qualifier+"Null["):
+		return field + " = " + qualifier + "NullValue(" + value + ")"
+	}
+	return field + " = " + value
+}
+
+// typeNames matches the names in a Go type that refer to the package the
+// type is written in, which are exported and not preceded by a qualifier.
+var typeNames = regexp.MustCompile(`(^|[^.\w])([A-Z]\w*)`)
+
+// qualifyType prefixes the names of the package-level types in typ with
+// qualifier.
+func qualifyType(typ, qualifier string) string {
+	return typeNames.ReplaceAllString(typ, "${1}"+qualifier+"${2}")
+}
+
+// wrapText breaks text into lines of at most width bytes, but for single
+// words that are longer.
+func wrapText(text string, width int) string {
+	var b strings.Builder
+	n := 0
+	for _, word := range strings.Fields(text) {
+		switch {
+		case n == 0:
+		case n+1+len(word) > width:
+			b.WriteByte('\n')
+			n = 0
+		default:
+			b.WriteByte(' ')
+			n++
+		}
+		b.WriteString(word)
+		n += len(word)
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package apiClient
+
+import "net/http"
+
+// contentRangeFunc, writeRangeFunc and downloadChunksFunc are the names of
+// the functions parsing a Content-Range header, writing the body of a
+// response to a Range request and downloading a resource in parallel
+// chunks, emitted alongside the client.
+const (
+	contentRangeFunc   = "ContentRange"
+	writeRangeFunc     = "WriteRange"
+	downloadChunksFunc = "DownloadChunks"
+)
+
+// buildRange adds to data, which has a Download method, the method writing
+// a range of the bytes of the binary bodies, such as GetPhotoRange, if it
+// is a GET, for which Range requests are defined.
+func (g *generator) buildRange(data *operationData) {
+	if data.Method != http.MethodGet {
+		return
+	}
+	if !g.usesRanges && (g.models[contentRangeFunc] || g.models[writeRangeFunc] || g.models[downloadChunksFunc]) {
+		g.log().Warn("function name for range requests is taken: no range method", "operation", data.Name, "function", writeRangeFunc)
+		return
+	}
+	g.usesRanges = true
+	g.models[contentRangeFunc], g.models[writeRangeFunc], g.models[downloadChunksFunc] = true, true, true
+	data.ContentRangeFunc, data.WriteRangeFunc = g.qualifier+contentRangeFunc, g.qualifier+writeRangeFunc
+
+	data.Range = data.Name + "Range"
+	if free := freeName(data.Range, g.methods); free != data.Range {
+		g.renamed("operation", data.Method+" "+data.Path+" range", data.Range, free)
+		data.Range = free
+	}
+	g.methods[data.Range] = true
+}
+
+// Ranged returns o as the data of its Range method.
+func (o operationData) Ranged() operationData {
+	o.Streaming, o.Ranging = true, true
+	return o
+}
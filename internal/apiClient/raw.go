@@ -0,0 +1,35 @@
+package apiClient
+
+import (
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// rawExtension, set to true, generates a schema as json.RawMessage, leaving
+// it to the caller to decode.
+const rawExtension = "x-go-raw"
+
+// rawJSONType is the Go type of raw JSON values.
+const rawJSONType = "json.RawMessage"
+
+// isRaw reports whether schema carries "x-go-raw: true".
+func isRaw(schema *base.Schema) bool {
+	return extension(schema, rawExtension) == "true"
+}
+
+// rawJSON reports whether the component schema named name is generated as
+// json.RawMessage, by Options.RawJSON or its x-go-raw extension.
+func (g *generator) rawJSON(name string, schema *base.Schema) bool {
+	return slices.Contains(g.opts.RawJSON, name) || isRaw(schema)
+}
+
+// isRawProxy reports whether proxy is generated as json.RawMessage, either
+// itself or as a reference to a schema that is, so that it is not a struct
+// despite its properties.
+func (g *generator) isRawProxy(proxy *base.SchemaProxy) bool {
+	if proxy.IsReference() && slices.Contains(g.opts.RawJSON, refName(proxy.GetReference())) {
+		return true
+	}
+	return isRaw(proxy.Schema())
+}
@@ -0,0 +1,50 @@
+package apiClient
+
+import (
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// isReadOnly reports whether a property is set by the server only, and
+// left out of request bodies.
+func isReadOnly(prop *base.SchemaProxy) bool {
+	schema := prop.Schema()
+	return schema != nil && schema.ReadOnly != nil && *schema.ReadOnly
+}
+
+// isWriteOnly reports whether a property is sent by the client only, and
+// left out of responses.
+func isWriteOnly(prop *base.SchemaProxy) bool {
+	schema := prop.Schema()
+	return schema != nil && schema.WriteOnly != nil && *schema.WriteOnly
+}
+
+// directionalRequired returns the required properties of props less the
+// read-only and write-only ones, which are required in one direction only.
+func directionalRequired(props *orderedmap.Map[string, *base.SchemaProxy], required []string) []string {
+	if orderedmap.Len(props) == 0 {
+		return required
+	}
+	return slices.DeleteFunc(slices.Clone(required), func(name string) bool {
+		prop, ok := props.Get(name)
+		return ok && (isReadOnly(prop) || isWriteOnly(prop))
+	})
+}
+
+// withoutProperties returns the properties of schema, including those of
+// its allOf members, and its required properties, less the ones for which
+// drop is true; it returns false if there are none to drop.
+func withoutProperties(schema *base.Schema, drop func(*base.SchemaProxy) bool) (*orderedmap.Map[string, *base.SchemaProxy], []string, bool) {
+	all := orderedmap.New[string, *base.SchemaProxy]()
+	var required []string
+	collectProperties(all, &required, schema, map[*base.Schema]bool{})
+	props := orderedmap.New[string, *base.SchemaProxy]()
+	for name, prop := range all.FromOldest() {
+		if !drop(prop) {
+			props.Set(name, prop)
+		}
+	}
+	return props, required, props.Len() < all.Len()
+}
@@ -0,0 +1,100 @@
+package apiClient
+
+import "testing"
+
+const redirectSpec = `
+openapi: 3.0.3
+info: {title: redirect, version: "1"}
+paths:
+  /hop/{n}:
+    get:
+      operationId: hop
+      parameters:
+        - {name: n, in: path, required: true, schema: {type: integer}}
+        - {name: to, in: query, schema: {type: string}}
+      responses:
+        "200": {description: ok}
+`
+
+const redirectMain = `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("other Authorization=%q Cookie=%q X-Trace=%q\n", r.Header.Get("Authorization"), r.Header.Get("Cookie"), r.Header.Get("X-Trace"))
+	}))
+	defer other.Close()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hop/"))
+		if n == 0 {
+			fmt.Printf("same Authorization=%q\n", r.Header.Get("Authorization"))
+			return
+		}
+		next := srv.URL
+		if r.URL.Query().Get("to") == "other" && n == 1 {
+			next = other.URL
+		}
+		http.Redirect(w, r, next+"/hop/"+strconv.Itoa(n-1)+"?"+r.URL.RawQuery, http.StatusFound)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	hop := func(policy client.RedirectPolicy, n int, to string) {
+		c := client.NewClient(srv.URL, client.WithRedirectPolicy(policy))
+		params := client.HopParams{N: n}
+		if to != "" {
+			params.To = &to
+		}
+		resp, err := c.Hop(ctx, params,
+			client.WithHeader("Authorization", "Bearer secret"),
+			client.WithHeader("Cookie", "session=1"),
+			client.WithHeader("X-Trace", "t"))
+		switch {
+		case errors.Is(err, client.ErrCrossOriginRedirect):
+			fmt.Println(n, "cross-origin redirect")
+		case err != nil:
+			fmt.Println(n, "error", strings.Contains(err.Error(), "stopped after"))
+		default:
+			fmt.Println(n, "status", resp.StatusCode())
+		}
+	}
+	hop(client.RedirectPolicy{}, 10, "")
+	hop(client.RedirectPolicy{}, 11, "")
+	hop(client.RedirectPolicy{MaxRedirects: 2}, 2, "")
+	hop(client.RedirectPolicy{MaxRedirects: 2}, 3, "")
+	hop(client.RedirectPolicy{MaxRedirects: -1}, 1, "")
+	hop(client.RedirectPolicy{KeepAuthorization: true}, 1, "")
+	hop(client.RedirectPolicy{KeepAuthorization: true}, 1, "other")
+	hop(client.RedirectPolicy{SameOrigin: true}, 1, "other")
+}
+`
+
+// TestRedirectPolicy checks that a RedirectPolicy limits the redirects
+// followed, keeps the Authorization header only on redirects to the same
+// origin, when asked to, drops the credentials on redirects to others, and
+// fails them when it allows the same origin only.
+func TestRedirectPolicy(t *testing.T) {
+	got := runGenerated(t, redirectSpec, redirectMain)
+	want := "same Authorization=\"\"\n10 status 200\n" +
+		"11 error true\n" +
+		"same Authorization=\"\"\n2 status 200\n" +
+		"3 error true\n" +
+		"1 status 302\n" +
+		"same Authorization=\"Bearer secret\"\n1 status 200\n" +
+		"other Authorization=\"\" Cookie=\"\" X-Trace=\"t\"\n1 status 200\n" +
+		"1 cross-origin redirect\n"
+	if got != want {
+		t.Errorf("redirects:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package apiClient
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Report describes the outcome of a generation run: every operation of
+// the document, whether it was generated, skipped or degraded, every
+// schema that was represented by a looser Go type than it describes, and
+// every identifier that had to be renamed.
+type Report struct {
+	Operations []OperationReport `json:"operations"`
+	Schemas    []SchemaReport    `json:"schemas"`
+	Renames    []RenameReport    `json:"renames,omitempty"`
+	Summary    ReportSummary     `json:"summary"`
+}
+
+// Operation statuses of a Report.
+const (
+	StatusGenerated = "generated"
+	// StatusDegraded marks a generated operation some of whose types fell
+	// back to interface{} or similar.
+	StatusDegraded = "degraded"
+	StatusSkipped  = "skipped"
+)
+
+// OperationReport is the outcome for one operation.
+type OperationReport struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	// Name is the generated method name; empty for skipped operations.
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status"`
+	// Reasons explain a skipped or degraded status.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// SchemaReport records a schema downgraded to a looser Go type.
+type SchemaReport struct {
+	Schema string `json:"schema"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// RenameReport records an identifier that collided with another, or could
+// not be derived, and the one generated instead.
+type RenameReport struct {
+	// Kind is "schema", "type", "property", "operation" or "enum".
+	Kind string `json:"kind"`
+	// Name locates the element in the document, such as Pet.user_id.
+	Name   string `json:"name"`
+	Wanted string `json:"wanted"`
+	Got    string `json:"got"`
+}
+
+// ReportSummary counts the operations of a Report by status.
+type ReportSummary struct {
+	Generated       int `json:"generated"`
+	Degraded        int `json:"degraded"`
+	Skipped         int `json:"skipped"`
+	DegradedSchemas int `json:"degradedSchemas"`
+	Renamed         int `json:"renamed"`
+}
+
+// log returns the logger generation decisions are reported to. Debug
+// records describe what is generated for each schema and operation; warning
+// records mark parts of the document that could not be represented
+// faithfully, such as schemas downgraded to interface{}.
+func (g *generator) log() *slog.Logger {
+	if g.opts.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return g.opts.Logger
+}
+
+// degraded reports that the schema at g.at is represented by the looser
+// Go type typ, or not at all if typ is empty.
+func (g *generator) degraded(typ, reason string) {
+	g.log().Warn("schema downgraded", "schema", g.at, "type", typ, "reason", reason)
+	if typ == "" {
+		g.degradations = append(g.degradations, fmt.Sprintf("%s: %s", g.at, reason))
+	} else {
+		g.degradations = append(g.degradations, fmt.Sprintf("%s is %s: %s", g.at, typ, reason))
+	}
+	if r := g.opts.Report; r != nil {
+		r.Schemas = append(r.Schemas, SchemaReport{Schema: g.at, Type: typ, Reason: reason})
+		r.Summary.DegradedSchemas++
+	}
+}
+
+// skipped reports that an operation, left out on purpose, is not generated.
+func (g *generator) skipped(method, path, operationID, reason string) {
+	g.log().Debug("skipped operation", "method", method, "path", path, "reason", reason)
+	if r := g.opts.Report; r != nil {
+		r.Operations = append(r.Operations, OperationReport{
+			Method:      method,
+			Path:        path,
+			OperationID: operationID,
+			Status:      StatusSkipped,
+			Reasons:     []string{reason},
+		})
+		r.Summary.Skipped++
+	}
+}
+
+// generated reports a generated operation and what was degraded in it.
+func (g *generator) generated(op *operationData, operationID string, degradations []string) {
+	g.log().Debug("generated operation", "method", op.Method, "path", op.Path, "name", op.Name, "tag", op.Tag)
+	if r := g.opts.Report; r != nil {
+		status := StatusGenerated
+		if len(degradations) > 0 {
+			status = StatusDegraded
+			r.Summary.Degraded++
+		} else {
+			r.Summary.Generated++
+		}
+		r.Operations = append(r.Operations, OperationReport{
+			Method:      op.Method,
+			Path:        op.Path,
+			OperationID: operationID,
+			Name:        op.Name,
+			Status:      status,
+			Reasons:     degradations,
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package apiClient
+
+// requestOption is the type of the per-call options that every generated
+// method takes last, emitted next to the client type.
+const requestOption = "RequestOption"
+
+// requestOptionNames are the identifiers of the RequestOption type and its
+// constructors, which schemas must not take.
+var requestOptionNames = []string{requestOption, "WithHeader", "WithQueryParam", "WithAccept", "WithTimeout", "WithIdempotencyKey", "Idempotent", "WithServerURL", "WithSecurity"}
+
+// clientOptionNames returns the identifiers of the constructor of the
+// client type client, its option type and their constructors, and the
+// default server URL.
+func clientOptionNames(client string) []string {
+	return []string{"New" + client, client + "Option", "DefaultServerURL", "ServerURLs", "WithServerVariable", "WithServers", "WithServerPolicy", "ServerPolicy", "PrimaryBackup", "RoundRobin", "WithHTTPClient", "WithAuthToken", "WithDefaultTimeout", "WithMaxRetries", "WithIdempotencyKeys", "WithDefaultHeaders", "RequestEditorFn", "WithRequestEditorFn", "WithStrictDecoding", "WithJSONCodec", "Codec", "NewCodec", "WithRedirectPolicy", "RedirectPolicy", "ErrCrossOriginRedirect", "WithResponseValidation", "ResponseMismatch", "WithRequestCompression", "ContentDecoder", "WithResponseDecompression", "WithETags", "WithCache", "CacheStore", "NewMemoryCache", "DoJSON"}
+}
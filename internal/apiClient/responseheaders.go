@@ -0,0 +1,136 @@
+package apiClient
+
+import (
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// responseHeaderData describes a header of the responses of an operation,
+// held by a field of its result type.
+type responseHeaderData struct {
+	// Name is the name of the header, such as X-Total-Count.
+	Name string
+	// Field is the field of the result holding it, Type its Go type and
+	// Doc its description.
+	Field string
+	Type  string
+	Doc   string
+	// Parse is the Go code parsing the value v of the header into value
+	// and err, empty for a string, and Split marks a list of strings
+	// separated by commas. Optional headers are held by pointers.
+	Parse    string
+	Split    bool
+	Optional bool
+}
+
+// buildResponseHeaders adds to the result type of data a field for each
+// header the responses of op that are not errors declare, typed as its
+// schema says. Content-Type is left to the Header method, as OpenAPI has
+// it.
+func (g *generator) buildResponseHeaders(data *operationData, op *v3.Operation) {
+	if op.Responses == nil {
+		return
+	}
+	taken := map[string]bool{"HTTPResponse": true, "Body": true, "StatusCode": true, "Status": true, "Header": true}
+	for _, r := range data.Responses {
+		taken[r.Field] = true
+	}
+	if data.ToFile != "" {
+		taken["Filename"] = true
+	}
+	if data.Method == http.MethodGet {
+		taken["NotModified"] = true
+	}
+	if data.Follow != "" {
+		taken["Links"] = true
+	}
+	seen := map[string]int{}
+	add := func(code string, resp *v3.Response) {
+		if isErrorStatus(code) || resp.Headers == nil {
+			return
+		}
+		for name, header := range resp.Headers.FromOldest() {
+			key := textproto.CanonicalMIMEHeaderKey(name)
+			if key == "Content-Type" || header == nil {
+				continue
+			}
+			if i, ok := seen[key]; ok {
+				// Required only if every response declaring it says so.
+				if h := &data.ResponseHeaders[i]; !header.Required && !h.Optional && !h.Split {
+					h.Optional, h.Type = true, "*"+h.Type
+				}
+				continue
+			}
+			g.at = data.Name + " " + name + " header"
+			h := g.responseHeader(name, header)
+			field := g.goName(name)
+			if field == "" || taken[field] {
+				field += "Header"
+			}
+			h.Field = freeName(field, taken)
+			if h.Field != g.goName(name) {
+				g.renamed("header", data.Name+" "+name, g.goName(name), h.Field)
+			}
+			taken[h.Field] = true
+			seen[key] = len(data.ResponseHeaders)
+			data.ResponseHeaders = append(data.ResponseHeaders, h)
+		}
+	}
+	for code, resp := range op.Responses.Codes.FromOldest() {
+		add(code, resp)
+	}
+	if op.Responses.Default != nil {
+		add("default", op.Responses.Default)
+	}
+}
+
+// responseHeader returns the field of the response header name, held as a
+// string unless its schema is a scalar of another type.
+func (g *generator) responseHeader(name string, header *v3.Header) responseHeaderData {
+	h := responseHeaderData{Name: name, Type: "string", Doc: strings.TrimSpace(header.Description), Optional: !header.Required}
+	var schema *base.Schema
+	if header.Schema != nil {
+		schema = header.Schema.Schema()
+	}
+	if schema != nil {
+		switch typ, format := schemaType(schema), schema.Format; {
+		case typ == "integer" && format == "int32":
+			h.Type, h.Parse = "int32", "n, err := strconv.ParseInt(v, 10, 32)\nvalue := int32(n)"
+		case typ == "integer" && format == "int64":
+			h.Type, h.Parse = "int64", "value, err := strconv.ParseInt(v, 10, 64)"
+		case typ == "integer":
+			h.Type, h.Parse = "int", "value, err := strconv.Atoi(v)"
+		case typ == "number" && format == "float":
+			h.Type, h.Parse = "float32", "n, err := strconv.ParseFloat(v, 32)\nvalue := float32(n)"
+		case typ == "number":
+			h.Type, h.Parse = "float64", "value, err := strconv.ParseFloat(v, 64)"
+		case typ == "boolean":
+			h.Type, h.Parse = "bool", "value, err := strconv.ParseBool(v)"
+		case typ == "string" && format == "date-time":
+			// RFC 3339 as the format says, or the HTTP date of headers such
+			// as Last-Modified.
+			h.Type, h.Parse = "time.Time", "value, err := time.Parse(time.RFC3339, v)\nif err != nil {\nvalue, err = http.ParseTime(v)\n}"
+		case typ == "string" && format == "date":
+			if h.Type = g.dateType(); h.Type != "string" {
+				h.Parse = "var value " + h.Type + "\nerr := value.UnmarshalText([]byte(v))"
+			}
+		case typ == "array" && schema.Items != nil && schema.Items.IsA():
+			if items := schema.Items.A.Schema(); items != nil && schemaType(items) == "string" {
+				h.Type, h.Split, h.Optional = "[]string", true, false
+			} else {
+				g.degraded("string", "header of an array of other than strings")
+			}
+		case typ != "string":
+			g.degraded("string", "header of a "+typ+" schema")
+		}
+	}
+	if h.Optional {
+		h.Type = "*" + h.Type
+	}
+	h.Parse = strings.ReplaceAll(h.Parse, "\n", "\n\t\t")
+	return h
+}
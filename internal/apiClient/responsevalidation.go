@@ -0,0 +1,172 @@
+package apiClient
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// componentSchemaPrefix begins the references to component schemas.
+const componentSchemaPrefix = "#/components/schemas/"
+
+// checkSchema is what WithResponseValidation checks of a schema of a JSON
+// response body, rendered as a responseSchema of the client: its JSON type,
+// if it has a single one, nullability, required and other properties,
+// items, enum values as JSON, allOf, and oneOf or anyOf alike. Ref names a
+// component schema, checked in its stead.
+type checkSchema struct {
+	Ref        string
+	Type       string
+	Nullable   bool
+	Required   []string
+	Properties []checkProperty
+	Items      *checkSchema
+	Enum       []string
+	AllOf      []*checkSchema
+	AnyOf      []*checkSchema
+}
+
+type checkProperty struct {
+	Name   string
+	Schema *checkSchema
+}
+
+// responseSchemaData is an entry of the responseSchemas of the client, and
+// Schema the responseSchema literal of its value.
+type responseSchemaData struct {
+	Key    string
+	Schema string
+}
+
+// addResponseSchema adds the schema of the JSON body of a response, such
+// as GET /pets/{id} 200, to the responseSchemas of the client, along with
+// the component schemas it refers to.
+func (g *generator) addResponseSchema(key string, proxy *base.SchemaProxy) {
+	if g.checkedSchemas == nil {
+		g.checkedSchemas = map[string]bool{}
+	}
+	if g.checkedSchemas[key] {
+		return
+	}
+	g.checkedSchemas[key] = true
+	g.responseSchemas = append(g.responseSchemas, responseSchemaData{Key: key, Schema: g.checkSchema(proxy, nil).literal()})
+}
+
+// checkSchema returns what is checked of the schema of proxy, adding the
+// component schemas it refers to. seen holds the other references being
+// followed, to stop at a cycle.
+func (g *generator) checkSchema(proxy *base.SchemaProxy, seen []string) *checkSchema {
+	if proxy == nil {
+		return &checkSchema{}
+	}
+	if ref := proxy.GetReference(); proxy.IsReference() {
+		if name, ok := strings.CutPrefix(ref, componentSchemaPrefix); ok {
+			if key := "#" + name; !g.checkedSchemas[key] {
+				g.checkedSchemas[key] = true
+				// Appended after the schemas it refers to, which is
+				// immaterial to the map.
+				c := g.checkSchema(base.CreateSchemaProxy(proxy.Schema()), nil)
+				g.responseSchemas = append(g.responseSchemas, responseSchemaData{Key: key, Schema: c.literal()})
+			}
+			return &checkSchema{Ref: name}
+		}
+		if slices.Contains(seen, ref) {
+			return &checkSchema{}
+		}
+		seen = append(seen, ref)
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		return &checkSchema{}
+	}
+	c := &checkSchema{Nullable: isNullable(schema)}
+	if types := slices.DeleteFunc(slices.Clone(schema.Type), func(t string) bool { return t == "null" }); len(types) == 1 {
+		c.Type = types[0]
+	} else if len(types) == 0 && schema.Properties != nil {
+		c.Type = "object"
+	}
+	for name, prop := range schema.Properties.FromOldest() {
+		if s := prop.Schema(); s != nil && s.WriteOnly != nil && *s.WriteOnly {
+			continue
+		}
+		c.Properties = append(c.Properties, checkProperty{Name: name, Schema: g.checkSchema(prop, seen)})
+		if slices.Contains(schema.Required, name) {
+			c.Required = append(c.Required, name)
+		}
+	}
+	if schema.Items != nil && schema.Items.IsA() {
+		c.Items = g.checkSchema(schema.Items.A, seen)
+	}
+	for _, value := range schema.Enum {
+		var v any
+		if value.Decode(&v) != nil {
+			continue
+		}
+		if b, err := json.Marshal(v); err == nil && string(b) != "null" {
+			c.Enum = append(c.Enum, string(b))
+		}
+	}
+	for _, member := range schema.AllOf {
+		c.AllOf = append(c.AllOf, g.checkSchema(member, seen))
+	}
+	for _, member := range nonNullMembers(append(slices.Clone(schema.OneOf), schema.AnyOf...)) {
+		c.AnyOf = append(c.AnyOf, g.checkSchema(member, seen))
+	}
+	return c
+}
+
+// literal returns the responseSchema composite literal of c, without its
+// type, as in a map or slice of them.
+func (c *checkSchema) literal() string {
+	var fields []string
+	if c.Ref != "" {
+		fields = append(fields, "Ref: "+strconv.Quote(c.Ref))
+	}
+	if c.Type != "" {
+		fields = append(fields, "Type: "+strconv.Quote(c.Type))
+	}
+	if c.Nullable {
+		fields = append(fields, "Nullable: true")
+	}
+	if len(c.Required) > 0 {
+		fields = append(fields, "Required: "+stringSlice(c.Required))
+	}
+	if len(c.Properties) > 0 {
+		props := make([]string, len(c.Properties))
+		for i, p := range c.Properties {
+			props[i] = strconv.Quote(p.Name) + ": " + p.Schema.literal()
+		}
+		fields = append(fields, "Properties: map[string]*responseSchema{"+strings.Join(props, ", ")+"}")
+	}
+	if c.Items != nil {
+		fields = append(fields, "Items: &responseSchema"+c.Items.literal())
+	}
+	if len(c.Enum) > 0 {
+		fields = append(fields, "Enum: "+stringSlice(c.Enum))
+	}
+	for _, members := range []struct {
+		name    string
+		schemas []*checkSchema
+	}{{"AllOf", c.AllOf}, {"AnyOf", c.AnyOf}} {
+		if len(members.schemas) == 0 {
+			continue
+		}
+		literals := make([]string, len(members.schemas))
+		for i, s := range members.schemas {
+			literals[i] = s.literal()
+		}
+		fields = append(fields, members.name+": []*responseSchema{"+strings.Join(literals, ", ")+"}")
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+func stringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
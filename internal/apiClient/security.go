@@ -0,0 +1,182 @@
+package apiClient
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// securitySchemeData describes a security scheme of the document that the
+// client has an option for, such as WithAPIKey for an API key,
+// WithBasicAuth for HTTP Basic authentication, WithAuthToken for a bearer
+// token or WithClientCredentials for the OAuth 2.0 client credentials flow.
+type securitySchemeData struct {
+	// Name is the name of the scheme in the document, and Option that of
+	// the client option setting its credentials.
+	Name   string
+	Option string
+	// Type is the type of the scheme: apiKey, basic for an http scheme of
+	// HTTP Basic authentication, clientCredentials for an oauth2 scheme
+	// with a client credentials flow, or bearer for any other scheme of
+	// bearer tokens: http bearer, oauth2 and openIdConnect.
+	Type string
+	// In and Param say where an API key is sent: as the header, query
+	// parameter or cookie Param.
+	In    string
+	Param string
+	// TokenURL is the URL the access tokens of the client credentials flow
+	// are requested from.
+	TokenURL string
+	// Description is that of the scheme, if any.
+	Description string
+}
+
+// buildSecuritySchemes returns the security schemes of the document the
+// client gets options for, in document order: those of API keys sent in a
+// header, a query parameter or a cookie, of HTTP Basic authentication, of
+// bearer tokens, which oauth2 and openIdConnect schemes send too, and of
+// OAuth 2.0 with a client credentials flow. The token of every bearer
+// scheme is that of WithAuthToken. The option of HTTP Basic authentication
+// is WithBasicAuth, and that of a client credentials flow
+// WithClientCredentials, unless the document has several of them: they are
+// then named after their scheme, such as WithAdminBasicAuth.
+func (g *generator) buildSecuritySchemes() []securitySchemeData {
+	if g.doc.Components == nil || g.doc.Components.SecuritySchemes == nil {
+		return nil
+	}
+	var schemes []securitySchemeData
+	for name, scheme := range g.doc.Components.SecuritySchemes.FromOldest() {
+		if scheme == nil {
+			continue
+		}
+		s := securitySchemeData{Name: name, Type: scheme.Type, Description: scheme.Description}
+		switch {
+		case scheme.Type == "apiKey" && slices.Contains([]string{"header", "query", "cookie"}, scheme.In) && scheme.Name != "":
+			s.In, s.Param = scheme.In, scheme.Name
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			s.Type = "basic"
+		case clientCredentialsFlow(scheme) != nil:
+			s.Type, s.TokenURL = "clientCredentials", clientCredentialsFlow(scheme).TokenUrl
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"), scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+			s.Type, s.Option = "bearer", "WithAuthToken"
+		default:
+			g.log().Warn("security scheme not supported: no client option", "scheme", name, "type", scheme.Type)
+			continue
+		}
+		schemes = append(schemes, s)
+	}
+
+	taken := map[string]bool{}
+	for _, name := range clientOptionNames(g.opts.ClientName) {
+		taken[name] = true
+	}
+	count := map[string]int{}
+	for _, s := range schemes {
+		count[s.Type]++
+	}
+	for i, s := range schemes {
+		if s.Type == "bearer" {
+			continue
+		}
+		option := "With" + g.goName(s.Name)
+		if suffix := securityOptionSuffixes[s.Type]; suffix != "" {
+			option = "With" + suffix
+			if count[s.Type] > 1 {
+				option = "With" + g.goName(s.Name) + suffix
+			}
+		}
+		if free := freeName(option, taken); free != option {
+			g.renamed("security scheme", s.Name, option, free)
+			option = free
+		}
+		taken[option] = true
+		schemes[i].Option = option
+	}
+	return schemes
+}
+
+// securityOptionSuffixes are the names of the options of the types of
+// schemes not named after their scheme, such as WithBasicAuth, unless the
+// document has several schemes of the type.
+var securityOptionSuffixes = map[string]string{
+	"basic":             "BasicAuth",
+	"clientCredentials": "ClientCredentials",
+}
+
+// clientCredentialsFlow returns the client credentials flow of scheme, if
+// it is an oauth2 scheme with one declaring its tokenUrl.
+func clientCredentialsFlow(scheme *v3.SecurityScheme) *v3.OAuthFlow {
+	if scheme == nil || scheme.Type != "oauth2" || scheme.Flows == nil {
+		return nil
+	}
+	if flow := scheme.Flows.ClientCredentials; flow != nil && flow.TokenUrl != "" {
+		return flow
+	}
+	return nil
+}
+
+// bearerSchemes returns the names of the schemes of bearer tokens among
+// schemes, whose token is that of WithAuthToken, unless WithClientCredentials
+// requests those of a client credentials flow.
+func bearerSchemes(schemes []securitySchemeData) []string {
+	var names []string
+	for _, s := range schemes {
+		if s.Type == "bearer" || s.Type == "clientCredentials" {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// usesClientCredentials reports whether one of schemes is a client
+// credentials flow, whose token source is then emitted.
+func usesClientCredentials(schemes []securitySchemeData) bool {
+	return slices.ContainsFunc(schemes, func(s securitySchemeData) bool { return s.Type == "clientCredentials" })
+}
+
+// securityOption returns the WithSecurity option of op, if it has security
+// requirements of its own rather than those of the document.
+func (g *generator) securityOption(op *v3.Operation) string {
+	if op.Security == nil {
+		return ""
+	}
+	var requirements []string
+	for _, alternative := range securityRequirements(op.Security) {
+		requirements = append(requirements, stringSlice(alternative))
+	}
+	return g.qualifier + "WithSecurity(" + strings.Join(requirements, ", ") + ")"
+}
+
+// securityLiteral returns the Go expression of the security requirements
+// reqs as a [][]string, or an empty string if reqs is nil.
+func securityLiteral(reqs []*base.SecurityRequirement) string {
+	if reqs == nil {
+		return ""
+	}
+	var alternatives []string
+	for _, alternative := range securityRequirements(reqs) {
+		alternatives = append(alternatives, stringSlice(alternative))
+	}
+	return "[][]string{" + strings.Join(alternatives, ", ") + "}"
+}
+
+// securityRequirements returns the names of the schemes of each of the
+// alternative requirements reqs.
+func securityRequirements(reqs []*base.SecurityRequirement) [][]string {
+	alternatives := [][]string{}
+	for _, req := range reqs {
+		if req == nil {
+			continue
+		}
+		schemes := []string{}
+		if req.Requirements != nil {
+			for name := range req.Requirements.KeysFromOldest() {
+				schemes = append(schemes, name)
+			}
+		}
+		alternatives = append(alternatives, schemes)
+	}
+	return alternatives
+}
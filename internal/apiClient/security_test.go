@@ -0,0 +1,121 @@
+package apiClient
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const securitySpec = `
+openapi: 3.0.3
+info: {title: security, version: "1"}
+security:
+  - bearerAuth: []
+paths:
+  /private:
+    get:
+      operationId: getPrivate
+      responses:
+        "204": {description: ok}
+  /public:
+    get:
+      operationId: getPublic
+      security: []
+      responses:
+        "204": {description: ok}
+  /keyed:
+    get:
+      operationId: getKeyed
+      security:
+        - api_key: []
+      responses:
+        "204": {description: ok}
+  /both:
+    get:
+      operationId: getBoth
+      security:
+        - api_key: []
+          bearerAuth: []
+      responses:
+        "204": {description: ok}
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+    api_key:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`
+
+const securityMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("%s %q %q\n", r.URL.Path, r.Header.Get("Authorization"), r.Header.Get("X-API-Key"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	c := client.NewClient(srv.URL, client.WithAuthToken("tok"), client.WithAPIKey("key"))
+	c.GetPrivate(ctx)
+	c.GetPublic(ctx)
+	c.GetKeyed(ctx)
+	c.GetBoth(ctx)
+	c = client.NewClient(srv.URL, client.WithAPIKey("key"))
+	c.GetBoth(ctx)
+}
+`
+
+// TestSecurityCredentials checks that the bearer token and the API key are
+// only sent to the operations requiring them, together when a requirement
+// has both.
+func TestSecurityCredentials(t *testing.T) {
+	got := runGenerated(t, securitySpec, securityMain)
+	want := `/private "Bearer tok" ""
+/public "" ""
+/keyed "" "key"
+/both "Bearer tok" "key"
+/both "" ""
+`
+	if got != want {
+		t.Errorf("sent credentials:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestBasicAuthOptions checks that the option of HTTP Basic authentication
+// is WithBasicAuth, or named after the scheme when there are several.
+func TestBasicAuthOptions(t *testing.T) {
+	const spec = `
+openapi: 3.0.3
+info: {title: basic, version: "1"}
+paths: {}
+components:
+  securitySchemes:
+%s`
+	tests := []struct {
+		schemes string
+		options []string
+	}{
+		{"    basic: {type: http, scheme: basic}\n", []string{"WithBasicAuth"}},
+		{"    admin: {type: http, scheme: Basic}\n    user: {type: http, scheme: basic}\n", []string{"WithAdminBasicAuth", "WithUserBasicAuth"}},
+	}
+	for _, tt := range tests {
+		src := string(generateFile(t, fmt.Sprintf(spec, tt.schemes)))
+		for _, option := range tt.options {
+			if !strings.Contains(src, "func "+option+"(username, password string) ClientOption") {
+				t.Errorf("schemes\n%sno %s option", tt.schemes, option)
+			}
+		}
+	}
+}
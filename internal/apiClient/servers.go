@@ -0,0 +1,122 @@
+package apiClient
+
+import (
+	"strconv"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// serverData describes the servers of the document. The client sends
+// requests to the first unless given other base URLs.
+type serverData struct {
+	// URL keeps the variables of the server in braces, such as {region}.
+	URL         string
+	Description string
+	// URLs are those of every server, if the document lists several.
+	URLs []string
+}
+
+// serverVariable is a variable of the server URL, set to Default unless the
+// client is given another value. Description is on a single line.
+type serverVariable struct {
+	Name        string
+	Default     string
+	Description string
+	Enum        []string
+}
+
+// Doc returns the item of v in the doc comment of WithServerVariable.
+func (v serverVariable) Doc() string {
+	doc := v.Name + ": "
+	if v.Description != "" {
+		doc += strings.TrimSuffix(v.Description, ".") + ", "
+	}
+	doc += strconv.Quote(v.Default) + " by default"
+	if len(v.Enum) > 0 {
+		values := make([]string, len(v.Enum))
+		for i, e := range v.Enum {
+			values[i] = strconv.Quote(e)
+		}
+		doc += ", one of " + strings.Join(values, ", ")
+	}
+	return doc + "."
+}
+
+// defaultServer returns the servers of the document, or nil if it lists
+// none.
+func (g *generator) defaultServer() *serverData {
+	var servers []*v3.Server
+	for _, s := range g.doc.Servers {
+		if s != nil {
+			servers = append(servers, s)
+		}
+	}
+	if len(servers) == 0 {
+		return nil
+	}
+	server := &serverData{URL: servers[0].URL, Description: strings.TrimSpace(servers[0].Description)}
+	if len(servers) > 1 {
+		for _, s := range servers {
+			server.URLs = append(server.URLs, s.URL)
+		}
+	}
+	return server
+}
+
+// serverVariables returns the variables of the servers of the document, of
+// its path items and of their operations, the first of a name taking
+// precedence, as they share the values the client is given.
+func (g *generator) serverVariables() []serverVariable {
+	var vars []serverVariable
+	seen := map[string]bool{}
+	add := func(servers []*v3.Server) {
+		for _, s := range servers {
+			if s == nil || s.Variables == nil {
+				continue
+			}
+			for name, v := range s.Variables.FromOldest() {
+				if v == nil || seen[name] {
+					continue
+				}
+				seen[name] = true
+				vars = append(vars, serverVariable{
+					Name:        name,
+					Default:     v.Default,
+					Description: strings.Join(strings.Fields(v.Description), " "),
+					Enum:        v.Enum,
+				})
+			}
+		}
+	}
+	add(g.doc.Servers)
+	if g.doc.Paths != nil {
+		for _, item := range g.doc.Paths.PathItems.FromOldest() {
+			add(item.Servers)
+			for _, op := range pathOperations(item) {
+				add(op.Servers)
+			}
+		}
+	}
+	return vars
+}
+
+// serverOption returns the WithServerURL option sending the requests of op
+// to its servers, or else to those of its path item, instead of those of
+// the client, or an empty string if neither has any.
+func (g *generator) serverOption(item *v3.PathItem, op *v3.Operation) string {
+	servers := op.Servers
+	if len(servers) == 0 {
+		servers = item.Servers
+	}
+	var urls []string
+	for _, s := range servers {
+		if s != nil {
+			urls = append(urls, strconv.Quote(s.URL))
+		}
+	}
+	if len(urls) == 0 {
+		return ""
+	}
+	return g.qualifier + "WithServerURL(" + strings.Join(urls, ", ") + ")"
+}
@@ -0,0 +1,68 @@
+package apiClient
+
+import "testing"
+
+const serversSpec = `
+openapi: 3.0.3
+info: {title: servers, version: "1"}
+servers:
+  - url: http://localhost/unused
+paths:
+  /ping:
+    get:
+      operationId: ping
+      responses:
+        "204": {description: ok}
+`
+
+const serversMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	server := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Print(name, " ")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	}
+	a, b := server("a"), server("b")
+	defer a.Close()
+	defer b.Close()
+	down := server("down")
+	down.Close()
+	ctx := context.Background()
+	ping := func(c *client.Client, n int, opts ...client.RequestOption) {
+		for range n {
+			if _, err := c.Ping(ctx, opts...); err != nil {
+				fmt.Print("error ")
+			}
+		}
+		fmt.Println()
+	}
+	ping(client.NewClient(down.URL, client.WithServers(down.URL, a.URL, b.URL)), 3)
+	ping(client.NewClient(a.URL, client.WithServers(a.URL, b.URL, down.URL), client.WithServerPolicy(client.RoundRobin())), 6)
+	ping(client.NewClient(a.URL, client.WithServers(down.URL)), 1)
+	ping(client.NewClient(a.URL), 1, client.WithServerURL(down.URL, b.URL))
+}
+`
+
+// TestServerFailover checks that a request goes to the next server when
+// one is down, in the order of the server policy, and fails when all are.
+func TestServerFailover(t *testing.T) {
+	got := runGenerated(t, serversSpec, serversMain)
+	want := "a a a \n" +
+		"a b a a b a \n" +
+		"error \n" +
+		"b \n"
+	if got != want {
+		t.Errorf("servers:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,186 @@
+package apiClient
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"go.yaml.in/yaml/v4"
+)
+
+// validateTag is the struct tag derived from the constraints of a schema,
+// in the syntax of github.com/go-playground/validator.
+const validateTag = "validate"
+
+// goTagsExtension adds struct tags to the field of a property, either as a
+// mapping such as {db: pet_id} or as a raw tag such as `db:"pet_id"`.
+const goTagsExtension = "x-go-tags"
+
+// omitEmptyTags are the mirrored tags that take the omitempty option of
+// optional fields.
+var omitEmptyTags = []string{"yaml", "xml", "toml", "bson", "msgpack"}
+
+func validateStructTags(tags []string) error {
+	for _, tag := range tags {
+		if !isIdentifier(tag) || tag == "json" {
+			return fmt.Errorf("invalid struct tag %q", tag)
+		}
+	}
+	return nil
+}
+
+// structTags returns the struct tags of f other than json: those named in
+// Options.StructTags and the xml tag, if the document has XML bodies, then
+// the ones of the x-go-tags extension of the property, which replace
+// generated tags of the same name.
+func (g *generator) structTags(f fieldData, prop *base.SchemaProxy, required bool) string {
+	type tag struct{ key, value string }
+	var tags []tag
+	set := func(key, value string) {
+		if i := slices.IndexFunc(tags, func(t tag) bool { return t.key == key }); i >= 0 {
+			tags[i].value = value
+			return
+		}
+		tags = append(tags, tag{key, value})
+	}
+
+	schema := prop.Schema()
+	for _, key := range g.opts.StructTags {
+		switch {
+		case key == validateTag:
+			if rules := validateRules(schema, f, required); rules != "" {
+				set(key, rules)
+			}
+		case f.Omit != "" && slices.Contains(omitEmptyTags, key):
+			set(key, f.JSONName+",omitempty")
+		default:
+			set(key, f.JSONName)
+		}
+	}
+	if g.xml {
+		set(xmlTag, xmlFieldTag(f, prop))
+	}
+
+	var raw string
+	if node, ok := extensionNode(schema, goTagsExtension); ok && !prop.IsReference() {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i].Value, node.Content[i+1].Value
+				if !isIdentifier(key) || key == "json" || strings.Contains(value, "`") {
+					g.log().Warn("invalid "+goTagsExtension+" entry, ignored", "schema", g.at, "tag", key)
+					continue
+				}
+				set(key, value)
+			}
+		case yaml.ScalarNode:
+			if strings.Contains(node.Value, "`") {
+				g.log().Warn("invalid "+goTagsExtension+", ignored", "schema", g.at)
+				break
+			}
+			raw = strings.TrimSpace(node.Value)
+		}
+	}
+
+	var b strings.Builder
+	for _, t := range tags {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.key + ":" + strconv.Quote(t.value))
+	}
+	if raw != "" {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(raw)
+	}
+	return b.String()
+}
+
+// validateRules returns the validate tag of f from the constraints of
+// schema. Fields of the Optional and Null types are left alone, since the
+// validator cannot see through them, and so is raw JSON.
+func validateRules(schema *base.Schema, f fieldData, required bool) string {
+	if schema == nil || strings.Contains(f.Type, "Optional[") || strings.Contains(f.Type, "Null[") || f.raw {
+		return ""
+	}
+	var rules []string
+	typ := schemaType(schema)
+	switch {
+	case !required:
+		rules = append(rules, "omitempty")
+	case typ == "string" || typ == "array" || typ == "object":
+		// Zero numbers and false are valid values of required fields.
+		rules = append(rules, "required")
+	}
+	rule := func(name string, n *int64) {
+		if n != nil {
+			rules = append(rules, name+"="+strconv.FormatInt(*n, 10))
+		}
+	}
+	bound := func(name string, x *float64) {
+		if x != nil {
+			rules = append(rules, name+"="+strconv.FormatFloat(*x, 'g', -1, 64))
+		}
+	}
+	switch typ {
+	case "string":
+		if f.Type != "string" && f.Type != "*string" {
+			// A mapped type such as time.Time has no length.
+			break
+		}
+		rule("min", schema.MinLength)
+		rule("max", schema.MaxLength)
+		switch schema.Format {
+		case "email", "uuid", "uri", "hostname", "ipv4", "ipv6":
+			rules = append(rules, schema.Format)
+		}
+	case "integer", "number":
+		if !isBasicNumber(strings.TrimPrefix(f.Type, "*")) {
+			// The validator cannot compare a mapped type such as Decimal.
+			break
+		}
+		// Exclusive bounds are flags in OpenAPI 3.0 and numbers in 3.1.
+		lower, upper := "gte", "lte"
+		var exclusiveMin, exclusiveMax *float64
+		if e := schema.ExclusiveMinimum; e != nil && e.IsA() && e.A {
+			lower = "gt"
+		} else if e != nil && e.IsB() {
+			exclusiveMin = &e.B
+		}
+		if e := schema.ExclusiveMaximum; e != nil && e.IsA() && e.A {
+			upper = "lt"
+		} else if e != nil && e.IsB() {
+			exclusiveMax = &e.B
+		}
+		bound(lower, schema.Minimum)
+		bound("gt", exclusiveMin)
+		bound(upper, schema.Maximum)
+		bound("lt", exclusiveMax)
+	case "array":
+		rule("min", schema.MinItems)
+		rule("max", schema.MaxItems)
+		if schema.UniqueItems != nil && *schema.UniqueItems {
+			rules = append(rules, "unique")
+		}
+	}
+	if len(rules) == 1 && rules[0] == "omitempty" {
+		return ""
+	}
+	return strings.Join(rules, ",")
+}
+
+// isBasicNumber reports whether typ is one of the integer and floating-point
+// types of Go.
+func isBasicNumber(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package apiClient
+
+import (
+	"embed"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// defaultTemplates holds the templates used when no override is given. The
+// entry point is the "file" template, executed with a fileData value.
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+var templateFuncs = template.FuncMap{
+	"lowerFirst": lowerFirst,
+	"comment":    comment,
+	"docStart":   docStart,
+}
+
+// loadTemplates parses the embedded templates and then every *.tmpl file in
+// dir, so that any {{define}} in dir replaces the default of the same name.
+func loadTemplates(dir string) (*template.Template, error) {
+	tmpl, err := template.New("oasgen").Funcs(templateFuncs).ParseFS(defaultTemplates, "templates/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parsing default templates: %w", err)
+	}
+	if dir == "" {
+		return tmpl, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("listing templates: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no *.tmpl files in %s", dir)
+	}
+	if tmpl, err = tmpl.ParseFiles(files...); err != nil {
+		return nil, fmt.Errorf("parsing templates: %w", err)
+	}
+	return tmpl, nil
+}
+
+// comment formats text as a Go line comment, one "// " line per input line.
+// Lines indented with a tab, the code blocks of doc comments, are kept so.
+func comment(text string) string {
+	text = strings.TrimLeft(strings.TrimRight(text, " \t\n"), " \n")
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "\t") {
+			lines[i] = "//" + line
+			continue
+		}
+		lines[i] = strings.TrimRight("// "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// docVerbs are the verbs that a summary or description may start with, in
+// the third person, such as "Lists pets", so that it follows the name it
+// documents as is.
+var docVerbs = map[string]bool{
+	"accept": true, "add": true, "apply": true, "archive": true, "assign": true,
+	"cancel": true, "change": true, "check": true, "clear": true, "clone": true,
+	"close": true, "compute": true, "confirm": true, "contain": true, "copy": true,
+	"count": true, "create": true, "delete": true, "describe": true, "disable": true,
+	"download": true, "enable": true, "export": true, "fetch": true, "find": true,
+	"generate": true, "get": true, "hold": true, "import": true, "invite": true,
+	"list": true, "load": true, "lock": true, "mark": true, "merge": true,
+	"modify": true, "move": true, "open": true, "patch": true, "publish": true,
+	"push": true, "put": true, "query": true, "read": true, "register": true,
+	"reject": true, "remove": true, "rename": true, "replace": true, "report": true,
+	"represent": true, "request": true, "reset": true, "resolve": true, "restore": true,
+	"retrieve": true, "return": true, "revoke": true, "run": true, "save": true,
+	"search": true, "send": true, "set": true, "show": true, "start": true,
+	"stop": true, "store": true, "stream": true, "submit": true, "subscribe": true,
+	"trigger": true, "unlock": true, "unsubscribe": true, "update": true, "upload": true,
+	"upsert": true, "validate": true, "verify": true, "watch": true, "write": true,
+}
+
+// docStart returns the doc comment text of name starting with text, a
+// summary or description, as a sentence of its own: "ListPets lists pets."
+// when text starts with a verb such as "Lists", "Pet is a pet." when it
+// starts with an article, and else "ListPets: List pets.". The first
+// paragraph gets a period if it ends without punctuation.
+func docStart(name, text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return name
+	}
+	first, rest, _ := strings.Cut(text, "\n\n")
+	if r := []rune(first); unicode.IsLetter(r[len(r)-1]) || unicode.IsDigit(r[len(r)-1]) {
+		text = first + "."
+		if rest != "" {
+			text += "\n\n" + rest
+		}
+	}
+	word, _, _ := strings.Cut(text, " ")
+	word = strings.ToLower(strings.TrimRight(word, ".,:;"))
+	switch {
+	case word == "a" || word == "an" || word == "the":
+		return name + " is " + lowerFirst(text)
+	case word == "is" || word == "are" || word == "has" || isDocVerb(word):
+		return name + " " + lowerFirst(text)
+	}
+	r := []rune(text)
+	r[0] = unicode.ToUpper(r[0])
+	return name + ": " + string(r)
+}
+
+// isDocVerb reports whether word is one of docVerbs in the third person,
+// such as lists, fetches or applies.
+func isDocVerb(word string) bool {
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return docVerbs[strings.TrimSuffix(word, "ies")+"y"]
+	case strings.HasSuffix(word, "es") && docVerbs[strings.TrimSuffix(word, "es")]:
+		return true
+	case strings.HasSuffix(word, "s"):
+		return docVerbs[strings.TrimSuffix(word, "s")]
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package apiClient
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// textMediaType is the media type of plain text bodies, which are kept as
+// a string rather than decoded.
+const textMediaType = "text/plain"
+
+// isText reports whether the media type name of content mt is plain text
+// or CSV, of a string schema or none.
+func isText(name string, mt *v3.MediaType) bool {
+	if mediaType, _, _ := strings.Cut(name, ";"); strings.TrimSpace(mediaType) != textMediaType && !isCSV(name, mt) {
+		return false
+	}
+	if mt.Schema == nil {
+		return true
+	}
+	schema := mt.Schema.Schema()
+	return schema == nil || schemaType(schema) == "string" && schema.Format != binaryFormat
+}
+
+// mediaTypes returns the media types of content that match, without their
+// parameters.
+func mediaTypes(content *orderedmap.Map[string, *v3.MediaType], match func(name string, mt *v3.MediaType) bool) []string {
+	var names []string
+	for name, mt := range content.FromOldest() {
+		if match(name, mt) {
+			name, _, _ = strings.Cut(name, ";")
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+	return names
+}
+
+// negotiate marks r, a body kept as it is of the given media types, as
+// Negotiated if content documents others as well, so that it is only set
+// when the Content-Type of the response is one of them.
+func (g *generator) negotiate(r *responseData, content *orderedmap.Map[string, *v3.MediaType], names []string) {
+	if orderedmap.Len(content) > len(names) {
+		r.Negotiated, r.MediaTypes = true, names
+		g.usesMediaTypes = true
+	}
+}
+
+// ContentTypeCheck returns the condition on the Content-Type of the
+// response that a Negotiated body of r is set or decoded under.
+func (r responseData) ContentTypeCheck() string {
+	const contentType = `resp.Header.Get("Content-Type")`
+	switch {
+	case r.Binary || r.Text || r.CSV || r.CodecMediaType != "":
+		args := []string{contentType}
+		for _, name := range r.MediaTypes {
+			args = append(args, strconv.Quote(name))
+		}
+		return "hasMediaType(" + strings.Join(args, ", ") + ")"
+	case r.XML:
+		return "isXML(" + contentType + ")"
+	}
+	return "isJSON(" + contentType + ")"
+}
+
+// Decoded reports whether the body of r is decoded into its field by
+// its Unmarshal function, rather than kept as it is, read as CSV or not read at all.
+func (r responseData) Decoded() bool {
+	return r.Field != "" && !r.Binary && !r.Text && !r.CSV
+}
+
+// ResponseDoc returns the doc comment of the result type of o, telling
+// what its fields hold.
+func (o operationData) ResponseDoc() string {
+	var binary, text, csv bool
+	decoded := []string{"JSON"}
+	for _, r := range o.Responses {
+		if r.Error {
+			continue
+		}
+		binary, text, csv = binary || r.Binary, text || r.Text, csv || r.CSV
+		var prefix string
+		switch {
+		case r.XML:
+			prefix = "XML"
+		case r.CodecMediaType != "":
+			prefix = codecMediaTypes[r.CodecMediaType]
+		}
+		if prefix != "" && !slices.Contains(decoded, prefix) {
+			decoded = append(decoded, prefix)
+		}
+	}
+	doc := o.Response + " is the result of " + o.Name + ". Each "
+	for i, prefix := range decoded {
+		switch {
+		case i == 0:
+		case i == len(decoded)-1:
+			doc += " or "
+		default:
+			doc += ", "
+		}
+		doc += prefix
+	}
+	doc += " field holds the decoded body of the status it is named after, if any"
+	var raw []string
+	if binary {
+		raw = append(raw, "each Binary field the body as it is")
+	}
+	if text {
+		raw = append(raw, "each Text field the body as a string")
+	}
+	if csv {
+		raw = append(raw, "each CSV field its rows")
+	}
+	for i, s := range raw {
+		if i == len(raw)-1 {
+			s = "and " + s
+		}
+		doc += ", " + s
+	}
+	return wrapText(doc+".", 76)
+}
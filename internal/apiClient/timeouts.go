@@ -0,0 +1,69 @@
+package apiClient
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// timeoutExtension sets the timeout of the calls of an operation, as a Go
+// duration such as "30s" or a number of seconds.
+const timeoutExtension = "x-timeout"
+
+// parseTimeout parses the value of an x-timeout or of Options.Timeouts.
+func parseTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q", value)
+	}
+	return d, nil
+}
+
+// timeoutOption returns the WithTimeout option bounding the calls of op, by
+// its entry in Options.Timeouts or else its x-timeout, or an empty string
+// if it has neither. An invalid x-timeout is ignored with a warning.
+func (g *generator) timeoutOption(op *v3.Operation) string {
+	value, ok := g.opts.Timeouts[op.OperationId]
+	if !ok && op.Extensions != nil {
+		node, found := op.Extensions.Get(timeoutExtension)
+		if !found || node == nil {
+			return ""
+		}
+		value = node.Value
+	}
+	d, err := parseTimeout(value)
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("timeout %q is not positive", value)
+	}
+	if err != nil {
+		g.log().Warn("timeout ignored", "operation", op.OperationId, "error", err)
+		return ""
+	}
+	return g.qualifier + "WithTimeout(" + durationExpr(d) + ")"
+}
+
+// durationExpr returns the Go expression of d in the largest unit dividing
+// it, such as 90 * time.Second.
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return strconv.FormatInt(int64(d/u.unit), 10) + " * " + u.name
+		}
+	}
+	return strconv.FormatInt(int64(d), 10)
+}
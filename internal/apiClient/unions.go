@@ -0,0 +1,124 @@
+package apiClient
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// unionData describes a type holding one of several member types, generated
+// for a oneOf schema, or for an anyOf schema, whose value may match several.
+type unionData struct {
+	// Discriminator is the JSON property selecting the member, if any.
+	Discriminator string
+	// Any marks an anyOf without discriminator, which holds every member
+	// the value matches.
+	Any     bool
+	Members []unionMember
+}
+
+// unionMember is one type of a union.
+type unionMember struct {
+	// Name is the suffix of the As and From accessors of the member.
+	Name string
+	Type string
+	// Cases lists the discriminator values selecting the member as Go
+	// literals, separated by commas; empty without a discriminator.
+	Cases string
+}
+
+// unionModel returns the model of the union type name for a oneOf or anyOf
+// schema, or false if schema is neither. owner names the schema in log
+// messages. Inline members other than references are declared as types of
+// their own, named after the union and their position, such as
+// ShapeOption2. An anyOf with a discriminator selects a single member, so
+// it is generated like a oneOf.
+func (g *generator) unionModel(owner, name string, schema *base.Schema) (modelData, bool) {
+	u := &unionData{}
+	members := nonNullMembers(schema.OneOf)
+	if len(members) < 2 {
+		members = nonNullMembers(schema.AnyOf)
+		u.Any = schema.Discriminator == nil
+	}
+	if len(members) < 2 {
+		return modelData{}, false
+	}
+	if d := schema.Discriminator; d != nil {
+		u.Discriminator = d.PropertyName
+	}
+	seen := map[string]bool{}
+	for i, proxy := range members {
+		var member unionMember
+		if proxy.IsReference() {
+			schemaName := refName(proxy.GetReference())
+			member.Name = g.schemaTypeName(schemaName)
+			member.Type = g.goType(proxy)
+			if u.Discriminator != "" {
+				member.Cases = discriminatorCases(schema.Discriminator, schemaName)
+			}
+		} else {
+			member.Name = "Option" + strconv.Itoa(i+1)
+			member.Type = g.memberType(owner, name+member.Name, proxy)
+		}
+		if seen[member.Type] {
+			continue
+		}
+		seen[member.Type] = true
+		u.Members = append(u.Members, member)
+	}
+
+	return modelData{
+		Name:        name,
+		Description: schema.Description,
+		Example:     typeExample(schema),
+		Deprecated:  isDeprecated(schema.Deprecated),
+		Union:       u,
+	}, true
+}
+
+// memberType declares the named type of an inline union member and returns
+// its name.
+func (g *generator) memberType(owner, name string, proxy *base.SchemaProxy) string {
+	schema := proxy.Schema()
+	if schema == nil {
+		return "interface{}"
+	}
+	if free := freeName(name, g.models); free != name {
+		g.renamed("type", owner, name, free)
+		name = free
+	}
+	g.models[name] = true
+	g.pending = append(g.pending, g.schemaModel(owner, name, "", proxy, schema))
+	return name
+}
+
+// discriminatorCases returns the discriminator values of the member
+// schemaName: the keys of the mapping referring to it, or else its name.
+func discriminatorCases(d *base.Discriminator, schemaName string) string {
+	var values []string
+	for value, ref := range d.Mapping.FromOldest() {
+		if refName(ref) == schemaName {
+			values = append(values, strconv.Quote(value))
+		}
+	}
+	if len(values) == 0 {
+		values = append(values, strconv.Quote(schemaName))
+	}
+	return strings.Join(values, ", ")
+}
+
+// nonNullMembers returns the members of a oneOf or anyOf that are not the
+// null type, which only makes the value nullable.
+func nonNullMembers(members []*base.SchemaProxy) []*base.SchemaProxy {
+	var out []*base.SchemaProxy
+	for _, proxy := range members {
+		if !proxy.IsReference() {
+			if schema := proxy.Schema(); schema != nil && len(schema.Type) == 1 && schema.Type[0] == "null" {
+				continue
+			}
+		}
+		out = append(out, proxy)
+	}
+	return out
+}
@@ -0,0 +1,310 @@
+package apiClient
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// validationError is the error type returned by the generated Validate
+// methods, emitted next to the client type, and violation that of the
+// violations it lists.
+const (
+	validationError = "ValidationError"
+	violation       = "Violation"
+)
+
+// validateData describes the Validate method of a struct model.
+type validateData struct {
+	// Error and Violation are the possibly qualified names of the
+	// ValidationError and Violation types.
+	Error     string
+	Violation string
+	Patterns  []patternData
+	Checks    []checkData
+}
+
+// patternData is a package-level regexp compiled from a pattern.
+type patternData struct {
+	Name string
+	Expr string
+}
+
+// checkData checks the value of a field, if Guard holds: against Rules,
+// then with its own Validate method if Nested, that of each of its
+// elements if Elements, or as the embedded struct it is if Embedded.
+// Receiver is Value without the dereference of a pointer, to call methods
+// on.
+type checkData struct {
+	Property string
+	Guard    string
+	Value    string
+	Receiver string
+	Rules    []ruleData
+	Nested   bool
+	Elements bool
+	Embedded bool
+}
+
+// ruleData fails with Reason when Fail holds.
+type ruleData struct {
+	Fail   string
+	Reason string
+}
+
+// buildValidation adds a Validate method to every struct model and Params
+// struct, and marks the operations whose request body or parameters have
+// one to call it before sending when Options.ValidateRequests is set. It
+// runs once every model is known, since checks depend on the final types of
+// fields and on which of them are structs or enums.
+func (g *generator) buildValidation(models []modelData, ops []operationData) {
+	if !g.validates() {
+		return
+	}
+	byName := map[string]*modelData{}
+	for i := range models {
+		byName[models[i].Name] = &models[i]
+	}
+	for i := range ops {
+		for j := range ops[i].Types {
+			byName[ops[i].Types[j].Name] = &ops[i].Types[j]
+		}
+	}
+
+	for i := range models {
+		g.validateModel(&models[i], byName, "")
+	}
+	for i := range ops {
+		op := &ops[i]
+		qualifier := ""
+		if g.opts.Layout == LayoutPackages && tagPackageName(op.Tag) != corePackage {
+			qualifier = corePackage + "."
+		}
+		for j := range op.Types {
+			g.validateModel(&op.Types[j], byName, qualifier)
+		}
+		if g.opts.ValidateRequests && op.RequestType != "" {
+			m := byName[strings.TrimPrefix(op.RequestType, qualifier)]
+			if m != nil && m.Alias {
+				// Request types of a referenced schema alias it.
+				m = byName[strings.TrimPrefix(m.Type, qualifier)]
+			}
+			op.ValidateRequest = m != nil && m.Validate != nil
+		}
+		if op.Params != "" {
+			g.validateParams(op, byName, qualifier)
+		}
+	}
+}
+
+// validateParams sets the Validate method of the Params struct of op, if
+// its parameters have constraints, and marks op to call it before sending
+// when Options.ValidateRequests is set.
+func (g *generator) validateParams(op *operationData, byName map[string]*modelData, qualifier string) {
+	m := modelData{Name: op.Params, Struct: true}
+	for _, f := range op.ParamFields() {
+		m.Fields = append(m.Fields, fieldData{Name: f.Field, Type: f.Type, JSONName: f.Name, schema: f.schema, optional: f.optional})
+	}
+	g.validateModel(&m, byName, qualifier)
+	if len(m.Validate.Checks) == 0 {
+		return
+	}
+	op.ParamsModel = &m
+	op.ValidateParams = g.opts.ValidateRequests
+}
+
+// validates reports whether Validate methods are generated.
+func (g *generator) validates() bool {
+	return g.opts.Validate || g.opts.ValidateRequests
+}
+
+// validateModel sets the Validate method of m if it is a struct. Types
+// declared in another package are prefixed with qualifier.
+func (g *generator) validateModel(m *modelData, byName map[string]*modelData, qualifier string) {
+	if !m.Struct {
+		return
+	}
+	v := &validateData{Error: qualifier + validationError, Violation: qualifier + violation}
+	for _, f := range m.Fields {
+		name := strings.TrimPrefix(f.Type, qualifier)
+		if f.Embedded {
+			if byName[name] != nil {
+				v.Checks = append(v.Checks, checkData{Value: "v." + name, Receiver: "v." + name, Embedded: true})
+			}
+			continue
+		}
+		c, typ, ok := fieldValue(f, qualifier)
+		if !ok {
+			continue
+		}
+		c.Property, c.Receiver = f.JSONName, strings.TrimPrefix(c.Value, "*")
+		if nested := byName[typ]; nested != nil && nested.Struct {
+			c.Nested = true
+		} else if elem, ok := strings.CutPrefix(typ, "[]"); ok && byName[elem] != nil && byName[elem].Struct {
+			c.Elements = true
+		} else if nested != nil && len(nested.Enum) > 0 {
+			c.Rules = append(c.Rules, ruleData{"!" + c.Receiver + ".IsValid()", "must be one of the values of " + nested.Name})
+		}
+		g.at = m.Name + "." + f.JSONName
+		c.Rules = append(c.Rules, g.constraintRules(v, m.Name+f.Name, f, typ, c.Value)...)
+		if len(c.Rules) == 0 && !c.Nested && !c.Elements {
+			continue
+		}
+		if c.Guard == "" && f.optional && len(c.Rules) > 0 {
+			c.Guard = zeroGuard(f)
+		}
+		v.Checks = append(v.Checks, c)
+	}
+	m.Validate = v
+}
+
+// fieldValue returns the check of f with the guard and value expression that
+// reach its value through a pointer, Optional or Null, along with the type
+// of that value without qualifier. Fields of x-go-type and raw JSON are not
+// checked.
+func fieldValue(f fieldData, qualifier string) (checkData, string, bool) {
+	if f.schema != nil && extension(f.schema, goTypeExtension) != "" || f.raw {
+		return checkData{}, "", false
+	}
+	field := "v." + f.Name
+	typ := f.Type
+	if elem, ok := strings.CutPrefix(typ, "*"); ok {
+		return checkData{Guard: field + " != nil", Value: "*" + field}, strings.TrimPrefix(elem, qualifier), true
+	}
+	if inner, ok := strings.CutPrefix(typ, qualifier+"Optional["); ok {
+		return checkData{Guard: "x, ok := " + field + ".Get(); ok", Value: "x"}, strings.TrimPrefix(strings.TrimSuffix(inner, "]"), qualifier), true
+	}
+	if inner, ok := strings.CutPrefix(typ, qualifier+"Null["); ok {
+		return checkData{Guard: field + ".Valid", Value: field + ".V"}, strings.TrimPrefix(strings.TrimSuffix(inner, "]"), qualifier), true
+	}
+	return checkData{Value: field}, strings.TrimPrefix(typ, qualifier), true
+}
+
+// zeroGuard returns the condition under which the optional field f, held
+// by value, is present: it is not the zero value of its type.
+func zeroGuard(f fieldData) string {
+	field := "v." + f.Name
+	switch {
+	case strings.HasPrefix(f.Type, "[]") || strings.HasPrefix(f.Type, "map["):
+		return field + " != nil"
+	case f.schema == nil:
+		return ""
+	}
+	switch schemaType(constraintSchema(f.schema)) {
+	case "string":
+		return field + ` != ""`
+	case "integer", "number":
+		return field + " != 0"
+	}
+	return ""
+}
+
+// constraintRules returns the rules of the constraints of the schema of f,
+// a value of the Go type typ at the expression value. Patterns are added to
+// v under names derived from prefix.
+func (g *generator) constraintRules(v *validateData, prefix string, f fieldData, typ, value string) []ruleData {
+	schema := constraintSchema(f.schema)
+	if schema == nil || g.mapsToNamedType(schema) {
+		return nil
+	}
+	var rules []ruleData
+	count := func(length string, min, max *int64, one, many string) {
+		limit := func(n int64) string {
+			if n == 1 {
+				return "1 " + one
+			}
+			return strconv.FormatInt(n, 10) + " " + many
+		}
+		if min != nil && *min > 0 {
+			rules = append(rules, ruleData{length + " < " + strconv.FormatInt(*min, 10), "must have at least " + limit(*min)})
+		}
+		if max != nil {
+			rules = append(rules, ruleData{length + " > " + strconv.FormatInt(*max, 10), "must have at most " + limit(*max)})
+		}
+	}
+	switch schemaType(schema) {
+	case "string":
+		if target, ok := g.typeMapping(schema); ok && target != "string" {
+			// A mapped type such as time.Time has no length.
+			return nil
+		}
+		count("utf8.RuneCountInString(string("+value+"))", schema.MinLength, schema.MaxLength, "character", "characters")
+		if schema.Pattern != "" {
+			if _, err := regexp.Compile(schema.Pattern); err != nil {
+				g.log().Warn("pattern is not a Go regular expression, not validated", "schema", g.at, "pattern", schema.Pattern)
+				break
+			}
+			name := unexportedName(prefix) + "Pattern"
+			v.Patterns = append(v.Patterns, patternData{Name: name, Expr: schema.Pattern})
+			rules = append(rules, ruleData{"!" + name + ".MatchString(string(" + value + "))", "must match the pattern " + schema.Pattern})
+		}
+	case "integer", "number":
+		integer := schemaType(schema) == "integer" && !strings.Contains(strings.ToLower(typ), "float")
+		bound := func(op string, x *float64, reason string) {
+			if x == nil {
+				return
+			}
+			lhs := value
+			if integer && *x != math.Trunc(*x) {
+				lhs = "float64(" + value + ")"
+			}
+			lit := strconv.FormatFloat(*x, 'g', -1, 64)
+			rules = append(rules, ruleData{lhs + " " + op + " " + lit, reason + " " + lit})
+		}
+		// Exclusive bounds are flags in OpenAPI 3.0 and numbers in 3.1.
+		if e := schema.ExclusiveMinimum; e != nil && e.IsA() && e.A {
+			bound("<=", schema.Minimum, "must be greater than")
+		} else {
+			bound("<", schema.Minimum, "must be at least")
+		}
+		if e := schema.ExclusiveMinimum; e != nil && e.IsB() {
+			bound("<=", &e.B, "must be greater than")
+		}
+		if e := schema.ExclusiveMaximum; e != nil && e.IsA() && e.A {
+			bound(">=", schema.Maximum, "must be less than")
+		} else {
+			bound(">", schema.Maximum, "must be at most")
+		}
+		if e := schema.ExclusiveMaximum; e != nil && e.IsB() {
+			bound(">=", &e.B, "must be less than")
+		}
+		if m := schema.MultipleOf; m != nil && *m > 0 {
+			if !integer || *m != math.Trunc(*m) {
+				g.log().Warn("multipleOf is only validated for integers", "schema", g.at)
+				break
+			}
+			lit := strconv.FormatFloat(*m, 'f', -1, 64)
+			rules = append(rules, ruleData{value + "%" + lit + " != 0", "must be a multiple of " + lit})
+		}
+	case "array":
+		if strings.HasPrefix(typ, "[]") {
+			count("len("+value+")", schema.MinItems, schema.MaxItems, "item", "items")
+		}
+	case "object":
+		if strings.HasPrefix(typ, "map[") {
+			count("len("+value+")", schema.MinProperties, schema.MaxProperties, "property", "properties")
+		}
+	}
+	return rules
+}
+
+// constraintSchema returns the schema holding the constraints of a
+// property schema, which is the single member of a nullable oneOf or anyOf
+// or of an allOf wrapping a reference.
+func constraintSchema(schema *base.Schema) *base.Schema {
+	if schema == nil {
+		return nil
+	}
+	for _, members := range [][]*base.SchemaProxy{schema.OneOf, schema.AnyOf} {
+		if members := nonNullMembers(members); len(members) == 1 {
+			return constraintSchema(members[0].Schema())
+		}
+	}
+	if len(schema.AllOf) == 1 && len(schema.Type) == 0 {
+		return constraintSchema(schema.AllOf[0].Schema())
+	}
+	return schema
+}
@@ -0,0 +1,141 @@
+package apiClient
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// xmlTag is the struct tag of encoding/xml.
+const xmlTag = "xml"
+
+// xmlContent returns the first XML media type of content, such as
+// application/xml, text/xml or one with an +xml suffix, and its name.
+func xmlContent(content *orderedmap.Map[string, *v3.MediaType]) (string, *v3.MediaType, bool) {
+	for name, mt := range content.FromOldest() {
+		if isXMLMediaType(name) {
+			return name, mt, true
+		}
+	}
+	return "", nil, false
+}
+
+func isXMLMediaType(name string) bool {
+	name, _, _ = strings.Cut(name, ";")
+	name = strings.TrimSpace(name)
+	return name == "application/xml" || name == "text/xml" || strings.HasSuffix(name, "+xml")
+}
+
+// buildXMLBody sets data, whose request body may be JSON or a form as well
+// as XML of the given media type, to send it as XML by a method such as
+// CreatePetWithXMLBody.
+func (g *generator) buildXMLBody(data *operationData, mediaType string) {
+	data.XMLContentType = mediaType
+	data.XMLBody = data.Name + "WithXMLBody"
+	if free := freeName(data.XMLBody, g.methods); free != data.XMLBody {
+		g.renamed("operation", data.Method+" "+data.Path+" with XML body", data.XMLBody, free)
+		data.XMLBody = free
+	}
+	g.methods[data.XMLBody] = true
+}
+
+// isXMLArray reports whether the schema of the XML media type mt is an
+// array, which encoding/xml cannot decode as a root element.
+func isXMLArray(mt *v3.MediaType) bool {
+	return mt.Schema != nil && mt.Schema.Schema() != nil && schemaType(mt.Schema.Schema()) == "array"
+}
+
+// documentUsesXML reports whether a request body or response of an
+// operation of doc is XML, so that the struct models get xml tags.
+func documentUsesXML(doc *v3.Document) bool {
+	return documentHasContent(doc, func(content *orderedmap.Map[string, *v3.MediaType]) bool {
+		_, _, ok := xmlContent(content)
+		return ok
+	})
+}
+
+// documentHasContent reports whether the content of a request body or
+// response of an operation of doc matches.
+func documentHasContent(doc *v3.Document, match func(content *orderedmap.Map[string, *v3.MediaType]) bool) bool {
+	if doc.Paths == nil {
+		return false
+	}
+	for _, item := range doc.Paths.PathItems.FromOldest() {
+		for _, op := range pathOperations(item) {
+			if op.RequestBody != nil && match(op.RequestBody.Content) {
+				return true
+			}
+			if op.Responses == nil {
+				continue
+			}
+			for _, resp := range op.Responses.Codes.FromOldest() {
+				if match(resp.Content) {
+					return true
+				}
+			}
+			if op.Responses.Default != nil && match(op.Responses.Default.Content) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// xmlFieldTag returns the xml tag of the field f of the property prop,
+// following its xml object: the element is named after the property
+// unless the object names it, an attribute if it says so, and an array is
+// a sequence of elements named after its items, within an element named
+// after the property if wrapped.
+func xmlFieldTag(f fieldData, prop *base.SchemaProxy) string {
+	schema := prop.Schema()
+	name := f.JSONName
+	var x *base.XML
+	if schema != nil && schema.XML != nil {
+		x = schema.XML
+		if x.Name != "" {
+			name = x.Name
+		}
+	}
+	if schema != nil && schemaType(schema) == "array" && schema.Items != nil && schema.Items.IsA() {
+		item := f.JSONName
+		if items := schema.Items.A.Schema(); items != nil && items.XML != nil && items.XML.Name != "" {
+			item = items.XML.Name
+		}
+		if x != nil && x.Wrapped {
+			name += ">" + item
+		} else {
+			name = item
+		}
+	}
+	if x != nil && x.Namespace != "" && !strings.Contains(name, ">") {
+		name = x.Namespace + " " + name
+	}
+	if x != nil && x.Attribute {
+		name += ",attr"
+	}
+	if f.Omit != "" && !strings.Contains(name, ">") {
+		// encoding/xml cannot leave out a parent element.
+		name += ",omitempty"
+	}
+	return name
+}
+
+// xmlRootName returns the name of the root element of the struct of
+// schema, from its xml object, or else name, that of the schema in the
+// document. It returns an empty string if neither names one, leaving
+// encoding/xml to name the element after the Go type.
+func xmlRootName(schema *base.Schema, name string) string {
+	var namespace string
+	if schema.XML != nil {
+		if schema.XML.Name != "" {
+			name = schema.XML.Name
+		}
+		namespace = schema.XML.Namespace
+	}
+	if name != "" && namespace != "" {
+		return namespace + " " + name
+	}
+	return name
+}
@@ -0,0 +1,198 @@
+package apiClient
+
+import "testing"
+
+const xmlSpec = `
+openapi: 3.0.3
+info: {title: xml, version: "1"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/xml:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        "201":
+          description: created
+          content:
+            application/xml:
+              schema: {$ref: '#/components/schemas/Pet'}
+  /widgets:
+    post:
+      operationId: createWidget
+      requestBody:
+        required: true
+        content:
+          application/xml:
+            schema: {$ref: '#/components/schemas/Widget'}
+      responses:
+        "204": {description: ok}
+  /tags:
+    post:
+      operationId: createTag
+      requestBody:
+        required: true
+        content:
+          application/xml:
+            schema: {$ref: '#/components/schemas/Tag'}
+      responses:
+        "204": {description: ok}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id: {type: integer, readOnly: true}
+        name: {type: string}
+    Widget:
+      type: object
+      x-go-name: Thing
+      properties:
+        name: {type: string}
+    Tag:
+      type: object
+      xml: {name: tag}
+      properties:
+        name: {type: string}
+`
+
+const xmlMain = `package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var root struct{ XMLName xml.Name }
+		if err := xml.NewDecoder(r.Body).Decode(&root); err != nil {
+			fmt.Println(r.URL.Path, err)
+		}
+		fmt.Println(r.URL.Path, root.XMLName.Local)
+		if r.URL.Path == "/pets" {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, "<Pet><id>1</id><name>x</name></Pet>")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL)
+	ctx := context.Background()
+	resp, err := c.CreatePet(ctx, client.CreatePetRequest{Name: "x"})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println("decoded", resp.XML201.Name)
+	}
+	if _, err := c.CreateWidget(ctx, client.Thing{Name: "x"}); err != nil {
+		fmt.Println(err)
+	}
+	if _, err := c.CreateTag(ctx, client.Tag{Name: "x"}); err != nil {
+		fmt.Println(err)
+	}
+}
+`
+
+// TestXMLRootElement checks that a struct is sent as the element named
+// after its schema in the document, or its xml object, rather than its Go
+// type.
+func TestXMLRootElement(t *testing.T) {
+	got := runGenerated(t, xmlSpec, xmlMain)
+	want := "/pets Pet\ndecoded x\n/widgets Widget\n/tags tag\n"
+	if got != want {
+		t.Errorf("posted root elements:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+const jsonXMLSpec = `
+openapi: 3.0.3
+info: {title: json and xml, version: "1"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+          application/xml:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+            application/xml:
+              schema: {$ref: '#/components/schemas/Pet'}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`
+
+const jsonXMLMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"example.com/gen/client"
+)
+
+func main() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Printf("%s %q %s\n", r.Header.Get("Content-Type"), r.Header.Get("Accept"), body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer srv.Close()
+	c := client.NewClient(srv.URL)
+	ctx := context.Background()
+	resp, err := c.CreatePet(ctx, client.Pet{Name: "json"})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(resp.JSON201.Name, resp.XML201 == nil)
+	}
+	resp, err = c.CreatePetWithXMLBody(ctx, client.Pet{Name: "xml"})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(resp.XML201.Name, resp.JSON201 == nil)
+	}
+}
+`
+
+// TestJSONAndXMLBodies checks that a body that may be JSON or XML is sent
+// as either, and that the response is decoded after its Content-Type.
+func TestJSONAndXMLBodies(t *testing.T) {
+	got := runGenerated(t, jsonXMLSpec, jsonXMLMain)
+	want := `application/json "application/json, application/xml" {"name":"json"}
+json true
+application/xml "application/json, application/xml" <Pet><name>xml</name></Pet>
+xml true
+`
+	if got != want {
+		t.Errorf("requests and results:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/bgw7/codegen-oas_http/internal/apiClient"
+	"github.com/bgw7/codegen-oas_http/internal/diff"
+)
+
+// compareFiles reports every generated file that differs from its copy on
+// disk below dir, either as a one-line summary or, with showDiff, as a
+// unified diff. It reports whether any file differs.
+func compareFiles(w io.Writer, dir string, files []apiClient.File, showDiff bool) (bool, error) {
+	stale := false
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.Name))
+		old, err := os.ReadFile(path)
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+		if exists && bytes.Equal(old, f.Content) {
+			continue
+		}
+		stale = true
+
+		if !showDiff {
+			if exists {
+				fmt.Fprintf(w, "would update %s\n", path)
+			} else {
+				fmt.Fprintf(w, "would create %s\n", path)
+			}
+			continue
+		}
+		oldName := "a/" + filepath.ToSlash(path)
+		if !exists {
+			oldName = "/dev/null"
+		}
+		w.Write(diff.Unified(oldName, "b/"+filepath.ToSlash(path), old, f.Content))
+	}
+	return stale, nil
+}
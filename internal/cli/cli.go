@@ -0,0 +1,350 @@
+// Package cli implements the oasgen command line. It is shared by
+// cmd/oasgen and by custom generators built with pkg/generator.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bgw7/codegen-oas_http/internal/apiClient"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+	exitStale = 3
+)
+
+// errUsage is returned by parseArgs after the problem has been reported.
+var errUsage = errors.New("usage error")
+
+// Run runs oasgen with the given arguments, not including the program
+// name, and returns the exit status.
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "watch" {
+		return runWatch(args[1:], stdout, stderr)
+	}
+	return runGenerate(args, stdout, stderr)
+}
+
+// invocation is the parsed command line.
+type invocation struct {
+	opts       apiClient.Options
+	configPath string
+	dryRun     bool
+	showDiff   bool
+	verbose    bool
+	reportPath string
+	log        *logger
+}
+
+// flagSet wraps flag.FlagSet with flags that override fields of
+// apiClient.Options. Overrides are applied on top of the config file and
+// only for flags given on the command line.
+type flagSet struct {
+	*flag.FlagSet
+	overrides []func(*apiClient.Options)
+}
+
+func (fs *flagSet) option(name, usage string, set func(*apiClient.Options, string)) {
+	fs.Func(name, usage, func(v string) error {
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) { set(o, v) })
+		return nil
+	})
+}
+
+func newFlagSet(name string, stderr io.Writer, inv *invocation) *flagSet {
+	def := apiClient.DefaultOptions()
+	fs := &flagSet{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError)}
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&inv.configPath, "config", "", "path to an oasgen.yaml or oasgen.json config file")
+	fs.BoolVar(&inv.verbose, "verbose", false, "log what is generated for every schema and operation, and list warnings")
+	// The first -spec replaces the documents of the config file; any
+	// further ones are merged into it.
+	specs := 0
+	fs.Func("spec", "path to the OpenAPI document, or - for stdin (required); repeat to merge several documents", func(v string) error {
+		first := specs == 0
+		specs++
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) {
+			if first {
+				o.SpecPath, o.MergeSpecPaths = v, nil
+			} else {
+				o.MergeSpecPaths = append(o.MergeSpecPaths, v)
+			}
+		})
+		return nil
+	})
+	fs.option("out", "path of the generated Go file (default "+def.OutPath+"), - for stdout, or output directory with -layout=split or packages (default .)", func(o *apiClient.Options, v string) {
+		o.OutPath = v
+	})
+	fs.option("module", "generate a standalone module with this path: go.mod, a client package and an example, rooted at -out (default .)", func(o *apiClient.Options, v string) {
+		o.Module = v
+	})
+	fs.option("layout", "file layout: single (default), split (models, client and one file per tag) or packages (core package plus one package per tag)", func(o *apiClient.Options, v string) {
+		o.Layout = apiClient.Layout(v)
+	})
+	fs.option("import-path", "import path of the output directory for -layout=packages (default derived from go.mod)", func(o *apiClient.Options, v string) {
+		o.ImportPath = v
+	})
+	fs.option("package", "package name of the generated code (default "+def.PackageName+")", func(o *apiClient.Options, v string) {
+		o.PackageName = v
+	})
+	fs.option("client-name", "name of the generated client type (default "+def.ClientName+")", func(o *apiClient.Options, v string) {
+		o.ClientName = v
+	})
+	fs.option("deprecated", "how to generate deprecated operations, schemas and properties: mark (default) adds a Deprecated: comment, skip leaves them out", func(o *apiClient.Options, v string) {
+		o.Deprecated = apiClient.DeprecatedPolicy(v)
+	})
+	fs.option("optional", "how to represent optional and nullable fields: value (default), pointer, optional for a generic Optional[T] or sql for a Null[T] embedding sql.Null[T]", func(o *apiClient.Options, v string) {
+		o.Optional = apiClient.OptionalStrategy(v)
+	})
+	fs.option("struct-tags", "struct tags to generate besides json, e.g. yaml,validate (comma-separated)", func(o *apiClient.Options, v string) {
+		o.StructTags = splitList(v)
+	})
+	fs.option("raw-json", "schemas and properties such as Pet.metadata to generate as json.RawMessage (comma-separated)", func(o *apiClient.Options, v string) {
+		o.RawJSON = splitList(v)
+	})
+	fs.option("templates-dir", "directory of *.tmpl files overriding the default templates", func(o *apiClient.Options, v string) {
+		o.TemplatesDir = v
+	})
+	fs.option("header-file", "file put at the top of every generated Go file, e.g. a license; may use {{.Year}} and {{.Date}}", func(o *apiClient.Options, v string) {
+		o.HeaderFile = v
+	})
+	// Like -spec, the first -post-hook replaces the hooks of the config
+	// file and further ones are added.
+	hooks := 0
+	fs.Func("post-hook", "command to run in the output directory after writing, e.g. \"goimports -w {files}\"; repeatable", func(v string) error {
+		first := hooks == 0
+		hooks++
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) {
+			if first {
+				o.PostHooks = nil
+			}
+			o.PostHooks = append(o.PostHooks, v)
+		})
+		return nil
+	})
+	fs.BoolFunc("lenient-enums", "accept unknown values when unmarshaling enum types", func(v string) error {
+		lenient, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) { o.LenientEnums = lenient })
+		return nil
+	})
+	fs.BoolFunc("validate", "generate Validate methods checking the constraints of the schemas", func(v string) error {
+		validate, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) { o.Validate = validate })
+		return nil
+	})
+	fs.BoolFunc("validate-requests", "generate Validate methods and call them on request bodies before sending", func(v string) error {
+		validate, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) { o.ValidateRequests = validate })
+		return nil
+	})
+	fs.BoolFunc("stream-arrays", "generate Items methods decoding the items of JSON array responses one at a time", func(v string) error {
+		stream, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) { o.StreamArrays = stream })
+		return nil
+	})
+	fs.BoolFunc("no-cache", "render every file, ignoring the build cache", func(v string) error {
+		noCache, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		fs.overrides = append(fs.overrides, func(o *apiClient.Options) { o.NoCache = noCache })
+		return nil
+	})
+
+	// Filter flags take comma-separated lists and replace the
+	// corresponding list of the config file.
+	for _, f := range []struct {
+		name, usage string
+		field       func(*apiClient.Filter) *[]string
+	}{
+		{"include-tags", "only generate operations with one of these tags", func(f *apiClient.Filter) *[]string { return &f.IncludeTags }},
+		{"exclude-tags", "skip operations with any of these tags", func(f *apiClient.Filter) *[]string { return &f.ExcludeTags }},
+		{"include-paths", "only generate operations on paths matching one of these patterns, e.g. /pets/**", func(f *apiClient.Filter) *[]string { return &f.IncludePaths }},
+		{"exclude-paths", "skip operations on paths matching any of these patterns", func(f *apiClient.Filter) *[]string { return &f.ExcludePaths }},
+		{"include-methods", "only generate operations with one of these HTTP methods", func(f *apiClient.Filter) *[]string { return &f.IncludeMethods }},
+		{"exclude-methods", "skip operations with any of these HTTP methods", func(f *apiClient.Filter) *[]string { return &f.ExcludeMethods }},
+		{"only-operations", "only generate the operations with these operationIds", func(f *apiClient.Filter) *[]string { return &f.IncludeOperations }},
+		{"exclude-operations", "skip the operations with these operationIds", func(f *apiClient.Filter) *[]string { return &f.ExcludeOperations }},
+	} {
+		fs.option(f.name, f.usage+" (comma-separated)", func(o *apiClient.Options, v string) {
+			*f.field(&o.Filter) = splitList(v)
+		})
+	}
+	return fs
+}
+
+// splitList splits a comma-separated flag value, dropping empty elements.
+func splitList(v string) []string {
+	var list []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// parseArgs parses args and merges them with the config file. It returns
+// errUsage when the usage message has been printed. Log records go to the
+// flag set's output, with timestamps if requested.
+func (fs *flagSet) parseArgs(args []string, inv *invocation, timestamps bool) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "oasgen: unexpected arguments: %v\n", fs.Args())
+		fs.Usage()
+		return errUsage
+	}
+	inv.log = newLogger(fs.Output(), inv.verbose, timestamps)
+
+	if inv.configPath == "" {
+		inv.configPath = apiClient.FindConfig(".")
+	}
+	opts, err := fs.options(inv.configPath)
+	if err != nil {
+		return err
+	}
+	inv.opts = opts
+	inv.opts.Logger = inv.log.Logger
+
+	if inv.opts.SpecPath == "" {
+		fmt.Fprintln(fs.Output(), "oasgen: -spec is required")
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// options reads the config file, if any, and applies the command line
+// overrides on top of it. Defaults are left to the generator, so that they
+// can depend on other settings such as the layout.
+func (fs *flagSet) options(configPath string) (apiClient.Options, error) {
+	var opts apiClient.Options
+	if configPath != "" {
+		cfg, err := apiClient.LoadConfig(configPath)
+		if err != nil {
+			return opts, err
+		}
+		cfg.Apply(&opts)
+	}
+	for _, set := range fs.overrides {
+		set(&opts)
+	}
+	return opts, nil
+}
+
+// exitCode reports err and maps it to an exit status.
+func (inv *invocation) exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		return exitUsage
+	}
+	inv.log.Error("generation failed", "err", err)
+	return exitError
+}
+
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+	var inv invocation
+	fs := newFlagSet("oasgen", stderr, &inv)
+	fs.BoolVar(&inv.dryRun, "dry-run", false, "list the files that would change without writing them")
+	fs.BoolVar(&inv.showDiff, "diff", false, "print a unified diff against the files on disk without writing; exit 3 if they differ")
+	fs.StringVar(&inv.reportPath, "report", "", "write a JSON report of generated, skipped and degraded operations to this file")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: oasgen -spec <file> [flags]\n       oasgen watch [flags]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.parseArgs(args, &inv, false); err != nil {
+		return inv.exitCode(err)
+	}
+	toStdout := inv.opts.OutPath == apiClient.StdioPath
+	if toStdout {
+		if inv.opts.Layout == apiClient.LayoutSplit || inv.opts.Layout == apiClient.LayoutPackages || inv.opts.Module != "" || inv.dryRun || inv.showDiff {
+			fmt.Fprintln(fs.Output(), "oasgen: -out - writes a single file and cannot be used with -layout=split or packages, -module, -dry-run or -diff")
+			return inv.exitCode(errUsage)
+		}
+		// Nothing on disk to reuse.
+		inv.opts.NoCache = true
+	}
+
+	var report apiClient.Report
+	if inv.reportPath != "" {
+		inv.opts.Report = &report
+	}
+	files, err := apiClient.Generate(inv.opts)
+	inv.log.summarize()
+	if err != nil {
+		return inv.exitCode(err)
+	}
+	if inv.reportPath != "" {
+		if err := writeReport(inv.reportPath, &report); err != nil {
+			return inv.exitCode(err)
+		}
+	}
+
+	if toStdout {
+		_, err := stdout.Write(files[0].Content)
+		return inv.exitCode(err)
+	}
+	if inv.dryRun || inv.showDiff {
+		stale, err := compareFiles(stdout, inv.opts.OutputDir(), files, inv.showDiff)
+		if err != nil {
+			return inv.exitCode(err)
+		}
+		if stale && inv.showDiff {
+			return exitStale
+		}
+		return exitOK
+	}
+
+	if err := apiClient.WriteFiles(inv.opts.OutputDir(), files); err != nil {
+		return inv.exitCode(err)
+	}
+	return inv.exitCode(apiClient.RunPostHooks(context.Background(), inv.opts, files))
+}
+
+func writeReport(path string, report *apiClient.Report) error {
+	// Spell empty lists as [] rather than null for consumers.
+	if report.Operations == nil {
+		report.Operations = []apiClient.OperationReport{}
+	}
+	if report.Schemas == nil {
+		report.Schemas = []apiClient.SchemaReport{}
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
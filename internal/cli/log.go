@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// logger reports on a run. Warnings are held back and printed together by
+// summarize, so that they are not lost among debug records; without
+// -verbose only their number is printed.
+type logger struct {
+	*slog.Logger
+	verbose bool
+	// base is the handler the Logger's handler prints through.
+	base     slog.Handler
+	warnings *warnings
+}
+
+func newLogger(w io.Writer, verbose, timestamps bool) *logger {
+	opts := &slog.HandlerOptions{Level: slog.LevelInfo}
+	if verbose {
+		opts.Level = slog.LevelDebug
+	}
+	if !timestamps {
+		opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		}
+	}
+	base := slog.NewTextHandler(w, opts)
+	ws := &warnings{}
+	h := &warningHandler{Handler: base, warnings: ws}
+	return &logger{Logger: slog.New(h), verbose: verbose, base: base, warnings: ws}
+}
+
+// summarize prints the warnings collected since the last call, or only
+// their number without -verbose.
+func (l *logger) summarize() {
+	held := l.warnings.take()
+	if len(held) == 0 {
+		return
+	}
+	ctx := context.Background()
+	if l.verbose {
+		for _, w := range held {
+			_ = w.handler.Handle(ctx, w.record)
+		}
+	}
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, "generated with warnings", 0)
+	r.AddAttrs(slog.Int("count", len(held)))
+	if !l.verbose {
+		r.AddAttrs(slog.String("hint", "run with -verbose to list them"))
+	}
+	_ = l.base.Handle(ctx, r)
+}
+
+// warnings collects held back warning records.
+type warnings struct {
+	mu   sync.Mutex
+	held []heldRecord
+}
+
+type heldRecord struct {
+	handler slog.Handler
+	record  slog.Record
+}
+
+func (w *warnings) add(h slog.Handler, r slog.Record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.held = append(w.held, heldRecord{h, r.Clone()})
+}
+
+func (w *warnings) take() []heldRecord {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	held := w.held
+	w.held = nil
+	return held
+}
+
+// warningHandler holds back records at warning level.
+type warningHandler struct {
+	slog.Handler
+	warnings *warnings
+}
+
+func (h *warningHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level != slog.LevelWarn {
+		return h.Handler.Handle(ctx, r)
+	}
+	h.warnings.add(h.Handler, r)
+	return nil
+}
+
+func (h *warningHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &warningHandler{Handler: h.Handler.WithAttrs(attrs), warnings: h.warnings}
+}
+
+func (h *warningHandler) WithGroup(name string) slog.Handler {
+	return &warningHandler{Handler: h.Handler.WithGroup(name), warnings: h.warnings}
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/bgw7/codegen-oas_http/internal/apiClient"
+)
+
+func runWatch(args []string, stdout, stderr io.Writer) int {
+	var inv invocation
+	var interval time.Duration
+	fs := newFlagSet("oasgen watch", stderr, &inv)
+	fs.DurationVar(&interval, "interval", 500*time.Millisecond, "how often to check the watched files for changes")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: oasgen watch -spec <file> [flags]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.parseArgs(args, &inv, true); err != nil {
+		return inv.exitCode(err)
+	}
+	if inv.opts.SpecPath == apiClient.StdioPath || slices.Contains(inv.opts.MergeSpecPaths, apiClient.StdioPath) || inv.opts.OutPath == apiClient.StdioPath {
+		fmt.Fprintln(fs.Output(), "oasgen: watch needs files: -spec - and -out - are not supported")
+		return inv.exitCode(errUsage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := &watcher{ctx: ctx, fs: fs, configPath: inv.configPath, log: inv.log}
+	w.regenerate()
+	last := snapshot(w.files)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case <-ticker.C:
+		}
+		cur := snapshot(w.files)
+		if !cur.equal(last) {
+			w.regenerate()
+			// The set of referenced files may have changed.
+			cur = snapshot(w.files)
+		}
+		last = cur
+	}
+}
+
+// watcher regenerates the client and tracks the files its output depends on.
+type watcher struct {
+	ctx        context.Context
+	fs         *flagSet
+	configPath string
+	log        *logger
+	// files are the inputs of the last generation attempt.
+	files []string
+}
+
+// regenerate reloads the config, regenerates the client and updates the
+// watched files. Errors are reported and leave the output untouched, so that
+// a half-edited spec does not stop the watch.
+func (w *watcher) regenerate() {
+	opts, err := w.fs.options(w.configPath)
+	if err == nil {
+		opts.Logger = w.log.Logger
+		err = w.generate(opts)
+	}
+	w.log.summarize()
+	if err != nil {
+		w.log.Error("generation failed", "err", err)
+		return
+	}
+	w.log.Info("generated", "dir", opts.OutputDir())
+}
+
+func (w *watcher) generate(opts apiClient.Options) error {
+	specPaths := append([]string{opts.SpecPath}, opts.MergeSpecPaths...)
+	files := slices.Clone(specPaths)
+	if w.configPath != "" {
+		files = append(files, w.configPath)
+	}
+	if opts.HeaderFile != "" {
+		files = append(files, opts.HeaderFile)
+	}
+	if opts.TemplatesDir != "" {
+		templates, _ := filepath.Glob(filepath.Join(opts.TemplatesDir, "*.tmpl"))
+		files = append(files, templates...)
+	}
+	// Keep watching what we know about even if loading fails.
+	defer func() { w.files = files }()
+
+	spec, err := apiClient.LoadSpecs(specPaths...)
+	if err != nil {
+		return err
+	}
+	files = append(files, spec.Files...)
+
+	out, err := apiClient.GenerateSpec(spec, opts)
+	if err != nil {
+		return err
+	}
+	if err := apiClient.WriteFiles(opts.OutputDir(), out); err != nil {
+		return err
+	}
+	return apiClient.RunPostHooks(w.ctx, opts, out)
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+type fileStates map[string]fileState
+
+// snapshot records the modification time and size of each file; missing
+// files are recorded with the zero state.
+func snapshot(files []string) fileStates {
+	s := make(fileStates, len(files))
+	for _, f := range files {
+		if fi, err := os.Stat(f); err == nil {
+			s[f] = fileState{fi.ModTime(), fi.Size()}
+		} else {
+			s[f] = fileState{}
+		}
+	}
+	return s
+}
+
+func (s fileStates) equal(o fileStates) bool {
+	if len(s) != len(o) {
+		return false
+	}
+	for f, st := range s {
+		ost, ok := o[f]
+		if !ok || !st.modTime.Equal(ost.modTime) || st.size != ost.size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+// Package diff produces unified diffs of line-oriented text.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// context is the number of unchanged lines shown around each change.
+const context = 3
+
+// Unified returns a unified diff turning old into new, or nil if they are
+// equal. The names label the "---" and "+++" lines.
+func Unified(oldName, newName string, old, new []byte) []byte {
+	if bytes.Equal(old, new) {
+		return nil
+	}
+	x, y := splitLines(old), splitLines(new)
+	edits := lineEdits(x, y)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(edits, len(x), len(y)) {
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(h.x0, h.x1), hunkRange(h.y0, h.y1))
+		i, j := h.x0, h.y0
+		for _, e := range edits[h.e0:h.e1] {
+			for ; i < e.x0; i, j = i+1, j+1 {
+				writeLine(&out, ' ', x[i])
+			}
+			for ; i < e.x1; i++ {
+				writeLine(&out, '-', x[i])
+			}
+			for ; j < e.y1; j++ {
+				writeLine(&out, '+', y[j])
+			}
+		}
+		for ; i < h.x1; i, j = i+1, j+1 {
+			writeLine(&out, ' ', x[i])
+		}
+	}
+	return out.Bytes()
+}
+
+// edit replaces x[x0:x1] with y[y0:y1].
+type edit struct {
+	x0, x1, y0, y1 int
+}
+
+// hunk is the range of lines, including context, printed under one "@@"
+// header, and the edits[e0:e1] it covers.
+type hunk struct {
+	x0, x1, y0, y1 int
+	e0, e1         int
+}
+
+func hunks(edits []edit, nx, ny int) []hunk {
+	var hs []hunk
+	for k, e := range edits {
+		h := hunk{
+			x0: max(e.x0-context, 0),
+			x1: min(e.x1+context, nx),
+			y0: max(e.y0-context, 0),
+			y1: min(e.y1+context, ny),
+			e0: k,
+			e1: k + 1,
+		}
+		if n := len(hs); n > 0 && h.x0 <= hs[n-1].x1 {
+			hs[n-1].x1, hs[n-1].y1, hs[n-1].e1 = h.x1, h.y1, h.e1
+			continue
+		}
+		hs = append(hs, h)
+	}
+	return hs
+}
+
+func hunkRange(start, end int) string {
+	switch n := end - start; n {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, n)
+	}
+}
+
+func writeLine(out *bytes.Buffer, prefix byte, line string) {
+	out.WriteByte(prefix)
+	out.WriteString(line)
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		out.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+func splitLines(b []byte) []string {
+	var lines []string
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n') + 1
+		if i == 0 {
+			i = len(b)
+		}
+		lines = append(lines, string(b[:i]))
+		b = b[i:]
+	}
+	return lines
+}
+
+// lineEdits computes the edits turning x into y. Lines that occur exactly
+// once in both inputs are used as anchors: the longest run of anchors in
+// the same order on both sides is matched, equal lines around each anchor
+// are grown outwards, and whatever remains between anchors becomes an edit.
+// This is not always a minimal diff, but runs in O(n log n) and reads well
+// for source code.
+func lineEdits(x, y []string) []edit {
+	type pair struct{ x, y int }
+
+	count := map[string][2]int{}
+	for _, l := range x {
+		c := count[l]
+		c[0]++
+		count[l] = c
+	}
+	for _, l := range y {
+		c := count[l]
+		c[1]++
+		count[l] = c
+	}
+	yIndex := map[string]int{}
+	for j, l := range y {
+		if count[l] == [2]int{1, 1} {
+			yIndex[l] = j
+		}
+	}
+	var unique []pair
+	for i, l := range x {
+		if count[l] == [2]int{1, 1} {
+			unique = append(unique, pair{i, yIndex[l]})
+		}
+	}
+
+	// Longest increasing subsequence of y indexes by patience sorting.
+	var tops []int
+	prev := make([]int, len(unique))
+	for k, p := range unique {
+		n := sort.Search(len(tops), func(i int) bool { return unique[tops[i]].y > p.y })
+		if n > 0 {
+			prev[k] = tops[n-1]
+		} else {
+			prev[k] = -1
+		}
+		if n == len(tops) {
+			tops = append(tops, k)
+		} else {
+			tops[n] = k
+		}
+	}
+	anchors := make([]pair, len(tops))
+	for k, i := len(tops)-1, -1; k >= 0; k-- {
+		if k == len(tops)-1 {
+			i = tops[k]
+		} else {
+			i = prev[i]
+		}
+		anchors[k] = unique[i]
+	}
+	anchors = append(anchors, pair{len(x), len(y)})
+
+	var edits []edit
+	i, j := 0, 0
+	for _, a := range anchors {
+		for i < a.x && j < a.y && x[i] == y[j] {
+			i, j = i+1, j+1
+		}
+		ex, ey := a.x, a.y
+		for ex > i && ey > j && x[ex-1] == y[ey-1] {
+			ex, ey = ex-1, ey-1
+		}
+		if i < ex || j < ey {
+			edits = append(edits, edit{i, ex, j, ey})
+		}
+		if a.x >= i {
+			i, j = a.x, a.y
+		}
+		for i < len(x) && j < len(y) && x[i] == y[j] {
+			i, j = i+1, j+1
+		}
+	}
+	return edits
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/bgw7/codegen-oas_http/internal/apiClient"
+)
+
+// Options configures a generation run. Start from DefaultOptions.
+type Options = apiClient.Options
+
+// Config is the content of an oasgen.yaml file, see LoadConfig.
+type Config = apiClient.Config
+
+// Spec is a parsed OpenAPI document, see Load.
+type Spec = apiClient.Spec
+
+// File is a generated file. Its Name is slash-separated and relative to
+// Options.OutputDir.
+type File = apiClient.File
+
+// Layout selects how generated code is split into files.
+type Layout = apiClient.Layout
+
+const (
+	LayoutSingle   = apiClient.LayoutSingle
+	LayoutSplit    = apiClient.LayoutSplit
+	LayoutPackages = apiClient.LayoutPackages
+)
+
+// DeprecatedPolicy selects how deprecated operations, schemas and
+// properties are generated.
+type DeprecatedPolicy = apiClient.DeprecatedPolicy
+
+const (
+	DeprecatedMark = apiClient.DeprecatedMark
+	DeprecatedSkip = apiClient.DeprecatedSkip
+)
+
+// OptionalStrategy selects how optional and nullable fields are
+// represented.
+type OptionalStrategy = apiClient.OptionalStrategy
+
+const (
+	OptionalValue   = apiClient.OptionalValue
+	OptionalPointer = apiClient.OptionalPointer
+	OptionalGeneric = apiClient.OptionalGeneric
+	OptionalSQL     = apiClient.OptionalSQL
+)
+
+// Filter selects the operations to generate.
+type Filter = apiClient.Filter
+
+// Naming configures how Go identifiers are derived from the document.
+type Naming = apiClient.Naming
+
+// DefaultInitialisms returns the words written in upper case in identifiers
+// when Naming.Initialisms is nil, such as ID and URL.
+func DefaultInitialisms() []string {
+	return apiClient.DefaultInitialisms()
+}
+
+// Report, when set as Options.Report, is filled with the outcome of a run.
+type (
+	Report          = apiClient.Report
+	OperationReport = apiClient.OperationReport
+	SchemaReport    = apiClient.SchemaReport
+	RenameReport    = apiClient.RenameReport
+	ReportSummary   = apiClient.ReportSummary
+)
+
+// DefaultOptions returns the options oasgen uses without flags or config.
+func DefaultOptions() Options {
+	return apiClient.DefaultOptions()
+}
+
+// LoadConfig reads a config file. Apply it to Options with Config.Apply.
+func LoadConfig(path string) (Config, error) {
+	return apiClient.LoadConfig(path)
+}
+
+// Load parses the OpenAPI document at paths[0] and merges the documents at
+// the remaining paths into it. A path of "-" reads from stdin.
+func Load(paths ...string) (*Spec, error) {
+	return apiClient.LoadSpecs(paths...)
+}
+
+// Generate loads the documents named by opts and returns the generated
+// files without writing them.
+func Generate(opts Options) ([]File, error) {
+	return apiClient.Generate(opts)
+}
+
+// GenerateSpec is like Generate for a document that is already loaded,
+// e.g. one modified by the caller. opts.SpecPath and opts.MergeSpecPaths
+// are ignored; registered pre passes run on spec.Document.
+func GenerateSpec(spec *Spec, opts Options) ([]File, error) {
+	return apiClient.GenerateSpec(spec, opts)
+}
+
+// WriteFiles writes files below dir, typically opts.OutputDir(), leaving
+// files whose content is unchanged untouched, and updates the build cache.
+func WriteFiles(dir string, files []File) error {
+	return apiClient.WriteFiles(dir, files)
+}
+
+// RunPostHooks runs opts.PostHooks once files have been written.
+func RunPostHooks(ctx context.Context, opts Options, files []File) error {
+	return apiClient.RunPostHooks(ctx, opts, files)
+}
@@ -0,0 +1,59 @@
+// Package generator lets other programs embed and extend oasgen without
+// patching it or shelling out to it.
+//
+// Build tools generate a client in process:
+//
+//	opts := generator.DefaultOptions()
+//	opts.SpecPath = "openapi.yaml"
+//	opts.OutPath = "client/client_gen.go"
+//	files, err := generator.Generate(opts)
+//	if err != nil {
+//		return err
+//	}
+//	return generator.WriteFiles(opts.OutputDir(), files)
+//
+// A custom generator registers its passes and then hands over to the
+// oasgen command line:
+//
+//	func main() {
+//		generator.Register(generator.PrePass(func(doc *v3.Document) error {
+//			// rewrite the document
+//			return nil
+//		}))
+//		generator.Register(generator.PostPass(func(name string, fset *token.FileSet, file *ast.File) error {
+//			// inject helpers into file
+//			return nil
+//		}))
+//		generator.Main()
+//	}
+package generator
+
+import (
+	"os"
+
+	"github.com/bgw7/codegen-oas_http/internal/apiClient"
+	"github.com/bgw7/codegen-oas_http/internal/cli"
+)
+
+// A Pass is a hook run during generation, either a PrePass or a PostPass.
+type Pass = apiClient.Pass
+
+// PrePass is run on the parsed OpenAPI document before any code is
+// generated, and may modify it.
+type PrePass = apiClient.PrePass
+
+// PostPass is run on the syntax tree of every generated Go file before it
+// is formatted and written, and may modify it. Imports are recomputed
+// after all post passes have run.
+type PostPass = apiClient.PostPass
+
+// Register adds a pass to every subsequent generation run. Passes run in
+// the order they were registered.
+func Register(p Pass) {
+	apiClient.Register(p)
+}
+
+// Main runs the oasgen command line with the registered passes and exits.
+func Main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}